@@ -0,0 +1,215 @@
+// Package analysis reconstructs work days from the raw stamps of AKASHI.
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/hapoon/kiku"
+)
+
+// MaxShift is the longest work day. A day which is still open after MaxShift is closed
+// as missing its clock-out, so that a forgotten clock-out does not swallow the next day.
+var MaxShift = 24 * time.Hour
+
+// AnomalyKind is the integer represents the kind of an anomaly found in stamps.
+type AnomalyKind int
+
+const (
+	// AnomalyMissingClockIn 出勤(直行)打刻なし
+	AnomalyMissingClockIn AnomalyKind = iota + 1
+	// AnomalyMissingClockOut 退勤(直帰)打刻なし
+	AnomalyMissingClockOut
+	// AnomalyUnpairedBreak 休憩戻のない休憩入
+	AnomalyUnpairedBreak
+	// AnomalyUnpairedBreakReturn 休憩入のない休憩戻
+	AnomalyUnpairedBreakReturn
+)
+
+func (a AnomalyKind) String() string {
+	switch a {
+	case AnomalyMissingClockIn:
+		return "出勤打刻なし"
+	case AnomalyMissingClockOut:
+		return "退勤打刻なし"
+	case AnomalyUnpairedBreak:
+		return "休憩戻打刻なし"
+	case AnomalyUnpairedBreakReturn:
+		return "休憩入打刻なし"
+	default:
+		return ""
+	}
+}
+
+// Anomaly is the struct representing an inconsistency in the stamps of a work day.
+type Anomaly struct {
+	Kind  AnomalyKind // 種類
+	Stamp kiku.Stamp  // 原因となった打刻
+}
+
+// Break is the struct representing a pair of break stamps.
+type Break struct {
+	Start time.Time // 休憩入時刻
+	End   time.Time // 休憩戻時刻
+}
+
+// Duration returns the length of the break.
+func (b Break) Duration() time.Duration {
+	return b.End.Sub(b.Start)
+}
+
+// WorkDay is the struct representing a work day reconstructed from stamps.
+// A work day belongs to the date it started on, even if it ends after midnight.
+type WorkDay struct {
+	StaffID    int          // 従業員ID
+	Date       time.Time    // 勤務日(kiku.Locationの0時)
+	Start      *time.Time   // 出勤・直行時刻
+	End        *time.Time   // 退勤・直帰時刻
+	GoStraight bool         // 直行したか
+	Bounce     bool         // 直帰したか
+	Breaks     []Break      // 休憩
+	Stamps     []kiku.Stamp // 勤務日に含まれる打刻
+	Anomalies  []Anomaly    // 打刻の不整合
+}
+
+// BreakDuration returns the total length of the paired breaks.
+func (w WorkDay) BreakDuration() (d time.Duration) {
+	for _, b := range w.Breaks {
+		d += b.Duration()
+	}
+	return
+}
+
+// WorkedDuration returns the length from Start to End excluding breaks.
+// It is zero unless both Start and End are known.
+func (w WorkDay) WorkedDuration() time.Duration {
+	if w.Start == nil || w.End == nil {
+		return 0
+	}
+	return w.End.Sub(*w.Start) - w.BreakDuration()
+}
+
+// Overnight reports whether the work day ends on a later date than it started.
+func (w WorkDay) Overnight() bool {
+	return w.End != nil && !dateOf(*w.End).Equal(w.Date)
+}
+
+// HasAnomaly reports whether any anomaly is found in the work day.
+func (w WorkDay) HasAnomaly() bool {
+	return len(w.Anomalies) > 0
+}
+
+// WorkDays groups the stamps of staffID into work days in chronological order.
+// Stamps without StampedAt are ignored.
+func WorkDays(staffID int, stamps []kiku.Stamp) (days []WorkDay) {
+	sorted := make([]kiku.Stamp, 0, len(stamps))
+	for _, s := range stamps {
+		if s.StampedAt != nil {
+			sorted = append(sorted, s)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StampedAt.Before(sorted[j].StampedAt.Time)
+	})
+
+	var (
+		current   *WorkDay
+		openBreak *kiku.Stamp
+	)
+	closeDay := func() {
+		if current == nil {
+			return
+		}
+		if openBreak != nil {
+			current.anomaly(AnomalyUnpairedBreak, *openBreak)
+			openBreak = nil
+		}
+		if current.End == nil {
+			current.anomaly(AnomalyMissingClockOut, current.Stamps[0])
+		}
+		days = append(days, *current)
+		current = nil
+	}
+	openDay := func(s kiku.Stamp) {
+		at := s.StampedAt.Time
+		current = &WorkDay{StaffID: staffID, Date: dateOf(at)}
+		switch s.Type {
+		case kiku.StampTypeGoToWork, kiku.StampTypeGoStraight:
+			current.Start = &at
+			current.GoStraight = s.Type == kiku.StampTypeGoStraight
+		default:
+			current.anomaly(AnomalyMissingClockIn, s)
+		}
+	}
+
+	for _, s := range sorted {
+		at := s.StampedAt.Time
+		if current != nil && at.Sub(current.Stamps[0].StampedAt.Time) > MaxShift {
+			closeDay()
+		}
+
+		switch s.Type {
+		case kiku.StampTypeGoToWork, kiku.StampTypeGoStraight:
+			closeDay()
+			openDay(s)
+		case kiku.StampTypeBreak:
+			if current == nil {
+				openDay(s)
+			}
+			if openBreak != nil {
+				current.anomaly(AnomalyUnpairedBreak, *openBreak)
+			}
+			b := s
+			openBreak = &b
+		case kiku.StampTypeBreakReturn:
+			if current == nil {
+				openDay(s)
+			}
+			if openBreak == nil {
+				current.anomaly(AnomalyUnpairedBreakReturn, s)
+			} else {
+				current.Breaks = append(current.Breaks, Break{Start: openBreak.StampedAt.Time, End: at})
+				openBreak = nil
+			}
+		case kiku.StampTypeLeaveWork, kiku.StampTypeBounce:
+			if current == nil {
+				openDay(s)
+			}
+			current.End = &at
+			current.Bounce = s.Type == kiku.StampTypeBounce
+		default:
+			continue
+		}
+
+		current.Stamps = append(current.Stamps, s)
+		if current.End != nil {
+			closeDay()
+		}
+	}
+	closeDay()
+	return
+}
+
+// WorkDaysByStaff groups the stamps of each response into work days per staff.
+// Responses for the same staff are merged.
+func WorkDaysByStaff(responses []kiku.GetStampResponse) map[int][]WorkDay {
+	stamps := map[int][]kiku.Stamp{}
+	for _, r := range responses {
+		stamps[r.StaffID] = append(stamps[r.StaffID], r.Stamps...)
+	}
+
+	days := make(map[int][]WorkDay, len(stamps))
+	for staffID, s := range stamps {
+		days[staffID] = WorkDays(staffID, s)
+	}
+	return days
+}
+
+func (w *WorkDay) anomaly(kind AnomalyKind, s kiku.Stamp) {
+	w.Anomalies = append(w.Anomalies, Anomaly{Kind: kind, Stamp: s})
+}
+
+func dateOf(t time.Time) time.Time {
+	t = t.In(kiku.Location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, kiku.Location)
+}
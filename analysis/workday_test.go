@@ -0,0 +1,177 @@
+package analysis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/hapoon/kiku/analysis"
+	"github.com/stretchr/testify/assert"
+)
+
+func stamp(typ kiku.StampType, day, hour, minute int) kiku.Stamp {
+	at := kiku.AkTime{Time: time.Date(2000, time.January, day, hour, minute, 0, 0, kiku.Location)}
+	return kiku.Stamp{StampedAt: &at, Type: typ}
+}
+
+func date(day int) time.Time {
+	return time.Date(2000, time.January, day, 0, 0, 0, 0, kiku.Location)
+}
+
+func Test_WorkDays(t *testing.T) {
+	type day struct {
+		date      time.Time
+		worked    time.Duration
+		breaks    time.Duration
+		overnight bool
+		anomalies []analysis.AnomalyKind
+	}
+	tests := map[string]struct {
+		stamps []kiku.Stamp
+		expect []day
+	}{
+		"Normal day": {
+			stamps: []kiku.Stamp{
+				stamp(kiku.StampTypeGoToWork, 1, 9, 0),
+				stamp(kiku.StampTypeBreak, 1, 12, 0),
+				stamp(kiku.StampTypeBreakReturn, 1, 13, 0),
+				stamp(kiku.StampTypeLeaveWork, 1, 18, 0),
+			},
+			expect: []day{{date: date(1), worked: 8 * time.Hour, breaks: time.Hour}},
+		},
+		"Unsorted stamps with multiple breaks": {
+			stamps: []kiku.Stamp{
+				stamp(kiku.StampTypeBreakReturn, 1, 15, 15),
+				stamp(kiku.StampTypeLeaveWork, 1, 18, 0),
+				stamp(kiku.StampTypeGoToWork, 1, 9, 0),
+				stamp(kiku.StampTypeBreak, 1, 12, 0),
+				stamp(kiku.StampTypeBreakReturn, 1, 12, 45),
+				stamp(kiku.StampTypeBreak, 1, 15, 0),
+			},
+			expect: []day{{date: date(1), worked: 8 * time.Hour, breaks: time.Hour}},
+		},
+		"Overnight shift": {
+			stamps: []kiku.Stamp{
+				stamp(kiku.StampTypeGoToWork, 1, 22, 0),
+				stamp(kiku.StampTypeBreak, 2, 2, 0),
+				stamp(kiku.StampTypeBreakReturn, 2, 3, 0),
+				stamp(kiku.StampTypeLeaveWork, 2, 7, 0),
+				stamp(kiku.StampTypeGoToWork, 2, 22, 0),
+				stamp(kiku.StampTypeLeaveWork, 3, 6, 0),
+			},
+			expect: []day{
+				{date: date(1), worked: 8 * time.Hour, breaks: time.Hour, overnight: true},
+				{date: date(2), worked: 8 * time.Hour, overnight: true},
+			},
+		},
+		"Go straight and bounce": {
+			stamps: []kiku.Stamp{
+				stamp(kiku.StampTypeGoStraight, 1, 8, 0),
+				stamp(kiku.StampTypeBounce, 1, 17, 0),
+			},
+			expect: []day{{date: date(1), worked: 9 * time.Hour}},
+		},
+		"Missing clock-out": {
+			stamps: []kiku.Stamp{
+				stamp(kiku.StampTypeGoToWork, 1, 9, 0),
+				stamp(kiku.StampTypeGoToWork, 2, 9, 0),
+				stamp(kiku.StampTypeLeaveWork, 2, 18, 0),
+			},
+			expect: []day{
+				{date: date(1), anomalies: []analysis.AnomalyKind{analysis.AnomalyMissingClockOut}},
+				{date: date(2), worked: 9 * time.Hour},
+			},
+		},
+		"Missing clock-in": {
+			stamps: []kiku.Stamp{
+				stamp(kiku.StampTypeBreak, 1, 12, 0),
+				stamp(kiku.StampTypeBreakReturn, 1, 13, 0),
+				stamp(kiku.StampTypeLeaveWork, 1, 18, 0),
+			},
+			expect: []day{
+				{date: date(1), breaks: time.Hour, anomalies: []analysis.AnomalyKind{analysis.AnomalyMissingClockIn}},
+			},
+		},
+		"Forgotten clock-out exceeds MaxShift": {
+			stamps: []kiku.Stamp{
+				stamp(kiku.StampTypeGoToWork, 1, 9, 0),
+				stamp(kiku.StampTypeLeaveWork, 2, 18, 0),
+			},
+			expect: []day{
+				{date: date(1), anomalies: []analysis.AnomalyKind{analysis.AnomalyMissingClockOut}},
+				{date: date(2), anomalies: []analysis.AnomalyKind{analysis.AnomalyMissingClockIn}},
+			},
+		},
+		"Unpaired break stamps": {
+			stamps: []kiku.Stamp{
+				stamp(kiku.StampTypeGoToWork, 1, 9, 0),
+				stamp(kiku.StampTypeBreakReturn, 1, 10, 0),
+				stamp(kiku.StampTypeBreak, 1, 12, 0),
+				stamp(kiku.StampTypeLeaveWork, 1, 18, 0),
+			},
+			expect: []day{
+				{date: date(1), worked: 9 * time.Hour, anomalies: []analysis.AnomalyKind{
+					analysis.AnomalyUnpairedBreakReturn, analysis.AnomalyUnpairedBreak,
+				}},
+			},
+		},
+		"No stamps": {},
+	}
+
+	for scenario, test := range tests {
+		days := analysis.WorkDays(1, test.stamps)
+		actual := make([]day, 0, len(days))
+		for _, d := range days {
+			var kinds []analysis.AnomalyKind
+			for _, a := range d.Anomalies {
+				kinds = append(kinds, a.Kind)
+			}
+			assert.Equal(t, 1, d.StaffID, scenario)
+			assert.Equal(t, len(kinds) > 0, d.HasAnomaly(), scenario)
+			actual = append(actual, day{
+				date:      d.Date,
+				worked:    d.WorkedDuration(),
+				breaks:    d.BreakDuration(),
+				overnight: d.Overnight(),
+				anomalies: kinds,
+			})
+		}
+		if test.expect == nil {
+			test.expect = []day{}
+		}
+		assert.Equal(t, test.expect, actual, scenario)
+	}
+}
+
+func Test_WorkDay_GoStraightAndBounce(t *testing.T) {
+	days := analysis.WorkDays(1, []kiku.Stamp{
+		stamp(kiku.StampTypeGoStraight, 1, 8, 0),
+		stamp(kiku.StampTypeLeaveWork, 1, 17, 0),
+		stamp(kiku.StampTypeGoToWork, 2, 9, 0),
+		stamp(kiku.StampTypeBounce, 2, 17, 0),
+	})
+
+	assert.Len(t, days, 2)
+	assert.True(t, days[0].GoStraight)
+	assert.False(t, days[0].Bounce)
+	assert.False(t, days[1].GoStraight)
+	assert.True(t, days[1].Bounce)
+	assert.Len(t, days[1].Stamps, 2)
+}
+
+func Test_WorkDaysByStaff(t *testing.T) {
+	responses := []kiku.GetStampResponse{
+		{StaffID: 1, Stamps: []kiku.Stamp{stamp(kiku.StampTypeGoToWork, 1, 9, 0)}},
+		{StaffID: 2, Stamps: []kiku.Stamp{stamp(kiku.StampTypeGoToWork, 1, 10, 0), stamp(kiku.StampTypeLeaveWork, 1, 19, 0)}},
+		{StaffID: 1, Stamps: []kiku.Stamp{stamp(kiku.StampTypeLeaveWork, 1, 18, 0)}},
+	}
+
+	actual := analysis.WorkDaysByStaff(responses)
+	assert.Len(t, actual, 2)
+	for staffID, days := range actual {
+		assert.Len(t, days, 1)
+		assert.Equal(t, staffID, days[0].StaffID)
+		assert.Equal(t, 9*time.Hour, days[0].WorkedDuration())
+		assert.False(t, days[0].HasAnomaly())
+	}
+}
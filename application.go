@@ -0,0 +1,91 @@
+package kiku
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ApplicationStatus is the integer represents the status of an application (申請).
+type ApplicationStatus int
+
+const (
+	// ApplicationStatusUnknown 申請状態:不明
+	ApplicationStatusUnknown ApplicationStatus = 0
+	// ApplicationStatusPending 申請状態:申請中
+	ApplicationStatusPending ApplicationStatus = 1
+	// ApplicationStatusApproved 申請状態:承認済
+	ApplicationStatusApproved ApplicationStatus = 2
+	// ApplicationStatusRejected 申請状態:却下
+	ApplicationStatusRejected ApplicationStatus = 3
+	// ApplicationStatusCanceled 申請状態:取消
+	ApplicationStatusCanceled ApplicationStatus = 4
+)
+
+func (a ApplicationStatus) String() string {
+	switch a {
+	case ApplicationStatusPending:
+		return "申請中"
+	case ApplicationStatusApproved:
+		return "承認済"
+	case ApplicationStatusRejected:
+		return "却下"
+	case ApplicationStatusCanceled:
+		return "取消"
+	default:
+		return ""
+	}
+}
+
+// ApplicationDecisionParam is the struct for the request parameters to approve or reject an application.
+type ApplicationDecisionParam struct {
+	LoginCompanyCode string `json:"-"`                 // AKASHI企業ID
+	Token            string `json:"token"`             // 承認者のアクセストークン
+	ApplicationID    int    `json:"-"`                 // 対象の申請ID
+	Comment          string `json:"comment,omitempty"` // 承認者のコメント
+}
+
+// IsValid is the function to verify ApplicationDecisionParam is correct.
+func (p ApplicationDecisionParam) IsValid() (err error) {
+	switch {
+	case p.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case p.Token == "":
+		err = errors.New("Token must be set")
+	case p.ApplicationID == 0:
+		err = errors.New("ApplicationID must be set")
+	}
+	return
+}
+
+// encodeURL encodes the URL to decide the application of kind, such as "/foo/applications/overtimes/1/approve".
+func (p ApplicationDecisionParam) encodeURL(kind, decision string) string {
+	return fmt.Sprintf("/%s/applications/%s/%d/%s", p.LoginCompanyCode, kind, p.ApplicationID, decision)
+}
+
+const (
+	decisionApprove = "approve"
+	decisionReject  = "reject"
+)
+
+// ApprovalStep is the struct representing a step of the approval route (承認経路).
+type ApprovalStep struct {
+	Order            int               `json:"order"`              // 承認順
+	ApproverStaffIDs []int             `json:"approver_staff_ids"` // 承認者の従業員IDの配列
+	Status           ApplicationStatus `json:"status"`             // このステップの承認状態
+	DecidedBy        int               `json:"decided_by"`         // 承認または却下した従業員ID
+	DecidedAt        *AkTime           `json:"decided_at"`         // 承認または却下した日時
+	Comment          string            `json:"comment"`            // 承認者のコメント
+}
+
+// Approvers returns the employees in staffs who can approve the step.
+func (a ApprovalStep) Approvers(staffs []Staff) (approvers []Staff) {
+	for _, s := range staffs {
+		for _, id := range a.ApproverStaffIDs {
+			if s.ID == id {
+				approvers = append(approvers, s)
+				break
+			}
+		}
+	}
+	return
+}
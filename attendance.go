@@ -0,0 +1,192 @@
+package kiku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// Minutes is the duration in minutes used by AKASHI.
+type Minutes int
+
+// Duration converts Minutes into time.Duration.
+func (m Minutes) Duration() time.Duration {
+	return time.Duration(m) * time.Minute
+}
+
+// String returns the duration in H:MM form, such as "8:05".
+func (m Minutes) String() string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+		m = -m
+	}
+	return fmt.Sprintf("%s%d:%02d", sign, m/60, m%60)
+}
+
+// DailyAttendance is the struct representing the attendance record of a day.
+type DailyAttendance struct {
+	Date               *AkTime `json:"date"`                 // 勤務日
+	WorkStartedAt      *AkTime `json:"work_started_at"`      // 出勤時刻
+	WorkEndedAt        *AkTime `json:"work_ended_at"`        // 退勤時刻
+	WorkingMinutes     Minutes `json:"working_minutes"`      // 労働時間
+	BreakMinutes       Minutes `json:"break_minutes"`        // 休憩時間
+	OvertimeMinutes    Minutes `json:"overtime_minutes"`     // 残業時間
+	LateNightMinutes   Minutes `json:"late_night_minutes"`   // 深夜労働時間
+	HolidayWorkMinutes Minutes `json:"holiday_work_minutes"` // 休日労働時間
+	LateMinutes        Minutes `json:"late_minutes"`         // 遅刻時間
+	EarlyLeaveMinutes  Minutes `json:"early_leave_minutes"`  // 早退時間
+	Holiday            bool    `json:"holiday"`              // 休日
+	Absent             bool    `json:"absent"`               // 欠勤
+}
+
+// MonthlyAttendance is the struct representing the attendance summary of a month.
+type MonthlyAttendance struct {
+	Year               int        // 年
+	Month              time.Month // 月
+	WorkingDays        int        // 出勤日数
+	HolidayWorkDays    int        // 休日出勤日数
+	AbsenceDays        int        // 欠勤日数
+	WorkingMinutes     Minutes    // 労働時間
+	BreakMinutes       Minutes    // 休憩時間
+	OvertimeMinutes    Minutes    // 残業時間
+	LateNightMinutes   Minutes    // 深夜労働時間
+	HolidayWorkMinutes Minutes    // 休日労働時間
+	LateMinutes        Minutes    // 遅刻時間
+	EarlyLeaveMinutes  Minutes    // 早退時間
+}
+
+// SummarizeAttendance totals the daily records per month in chronological order.
+// Records without Date are ignored.
+func SummarizeAttendance(records []DailyAttendance) (summaries []MonthlyAttendance) {
+	index := map[[2]int]int{}
+	for _, r := range records {
+		if r.Date == nil {
+			continue
+		}
+		key := [2]int{r.Date.Year(), int(r.Date.Month())}
+		i, ok := index[key]
+		if !ok {
+			i = len(summaries)
+			index[key] = i
+			summaries = append(summaries, MonthlyAttendance{Year: key[0], Month: time.Month(key[1])})
+		}
+
+		s := &summaries[i]
+		switch {
+		case r.Absent:
+			s.AbsenceDays++
+		case r.Holiday && r.WorkingMinutes > 0:
+			s.HolidayWorkDays++
+		case r.WorkingMinutes > 0:
+			s.WorkingDays++
+		}
+		s.WorkingMinutes += r.WorkingMinutes
+		s.BreakMinutes += r.BreakMinutes
+		s.OvertimeMinutes += r.OvertimeMinutes
+		s.LateNightMinutes += r.LateNightMinutes
+		s.HolidayWorkMinutes += r.HolidayWorkMinutes
+		s.LateMinutes += r.LateMinutes
+		s.EarlyLeaveMinutes += r.EarlyLeaveMinutes
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Year != summaries[j].Year {
+			return summaries[i].Year < summaries[j].Year
+		}
+		return summaries[i].Month < summaries[j].Month
+	})
+	return
+}
+
+// GetAttendanceParam is the struct for the request parameters of GET Attendance API.
+type GetAttendanceParam struct {
+	LoginCompanyCode string     // AKASHI企業ID
+	Token            string     // アクセストークン
+	StaffID          int        // 取得対象の従業員ID
+	StartDate        *time.Time // 取得期間の開始日
+	EndDate          *time.Time // 取得期間の終了日
+}
+
+// IsValid is the function to verify GetAttendanceParam is correct.
+func (g GetAttendanceParam) IsValid() (err error) {
+	switch {
+	case g.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case g.Token == "":
+		err = errors.New("Token must be set")
+	case g.StaffID == 0:
+		err = errors.New("StaffID must be set")
+	case g.StartDate == nil:
+		err = errors.New("StartDate must be set")
+	case g.EndDate == nil:
+		err = errors.New("EndDate must be set")
+	case g.EndDate.Before(*g.StartDate):
+		err = errors.New("EndDate must not be before StartDate")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of GET Attendance API.
+func (g GetAttendanceParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/attendances/%d", g.LoginCompanyCode, g.StaffID)
+
+	uv := url.Values{}
+	uv.Add("token", g.Token)
+	if g.StartDate != nil {
+		uv.Add("start_date", g.StartDate.In(Location).Format(DateFormat))
+	}
+	if g.EndDate != nil {
+		uv.Add("end_date", g.EndDate.In(Location).Format(DateFormat))
+	}
+	encodedURL += "?" + uv.Encode()
+	return
+}
+
+// GetAttendanceResponse is the struct representing the response of GET Attendance API.
+type GetAttendanceResponse struct {
+	LoginCompanyCode string            `json:"login_company_code"` // AKASHI企業ID
+	StaffID          int               `json:"staff_id"`           // 従業員ID
+	Count            int               `json:"count"`              // 勤怠データ数
+	Attendances      []DailyAttendance `json:"attendances"`        // 日別の勤怠データの配列
+}
+
+// Decode is the function that decodes the response of GET Attendance API.
+func (g *GetAttendanceResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, g)
+}
+
+// Monthly returns the monthly summaries of the attendance records.
+func (g GetAttendanceResponse) Monthly() []MonthlyAttendance {
+	return SummarizeAttendance(g.Attendances)
+}
+
+// GetAttendance is the function that retrieves attendance records of an employee from AKASHI.
+func GetAttendance(ctx context.Context, param GetAttendanceParam) (response GetAttendanceResponse, err error) {
+	return defaultClient.GetAttendance(ctx, param)
+}
+
+// GetAttendance is the method that retrieves attendance records of an employee from AKASHI.
+func (c *Client) GetAttendance(ctx context.Context, param GetAttendanceParam) (response GetAttendanceResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "GetAttendance", param.LoginCompanyCode, param.StaffID)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.get(ctx, param.LoginCompanyCode, param.EncodeURL())
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
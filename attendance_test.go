@@ -0,0 +1,106 @@
+package kiku_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Minutes(t *testing.T) {
+	tests := map[string]struct {
+		m        kiku.Minutes
+		duration time.Duration
+		str      string
+	}{
+		"Zero":     {m: 0, duration: 0, str: "0:00"},
+		"Hours":    {m: 485, duration: 8*time.Hour + 5*time.Minute, str: "8:05"},
+		"Negative": {m: -30, duration: -30 * time.Minute, str: "-0:30"},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.duration, test.m.Duration(), scenario)
+		assert.Equal(t, test.str, test.m.String(), scenario)
+	}
+}
+
+func Test_GetAttendanceParam_IsValid(t *testing.T) {
+	startDate := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2000, time.January, 31, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		g      kiku.GetAttendanceParam
+		expect error
+	}{
+		"Normal scenario": {
+			g: kiku.GetAttendanceParam{LoginCompanyCode: "foo", Token: "bar", StaffID: 1, StartDate: &startDate, EndDate: &endDate},
+		},
+		"Error scenario: StaffID is empty": {
+			g:      kiku.GetAttendanceParam{LoginCompanyCode: "foo", Token: "bar", StartDate: &startDate, EndDate: &endDate},
+			expect: errors.New("StaffID must be set"),
+		},
+		"Error scenario: StartDate is empty": {
+			g:      kiku.GetAttendanceParam{LoginCompanyCode: "foo", Token: "bar", StaffID: 1, EndDate: &endDate},
+			expect: errors.New("StartDate must be set"),
+		},
+		"Error scenario: EndDate is before StartDate": {
+			g:      kiku.GetAttendanceParam{LoginCompanyCode: "foo", Token: "bar", StaffID: 1, StartDate: &endDate, EndDate: &startDate},
+			expect: errors.New("EndDate must not be before StartDate"),
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.expect, test.g.IsValid(), scenario)
+	}
+}
+
+func Test_GetAttendanceParam_EncodeURL(t *testing.T) {
+	startDate := time.Date(2000, time.January, 1, 0, 0, 0, 0, kiku.Location)
+	endDate := time.Date(2000, time.January, 31, 0, 0, 0, 0, kiku.Location)
+	g := kiku.GetAttendanceParam{LoginCompanyCode: "foo", Token: "bar", StaffID: 1, StartDate: &startDate, EndDate: &endDate}
+	assert.Equal(t, "/foo/attendances/1?end_date=20000131000000&start_date=20000101000000&token=bar", g.EncodeURL())
+}
+
+func Test_GetAttendanceResponse_Decode(t *testing.T) {
+	var actual kiku.GetAttendanceResponse
+	err := actual.Decode(strings.NewReader(`{
+		"success":true,
+		"response":{
+			"login_company_code":"foo",
+			"staff_id":1,
+			"count":3,
+			"attendances":[
+				{"date":"2000/01/31 00:00:00","work_started_at":"2000/01/31 09:00:00","work_ended_at":"2000/01/31 19:00:00","working_minutes":540,"break_minutes":60,"overtime_minutes":60},
+				{"date":"2000/02/01 00:00:00","absent":true},
+				{"date":"2000/02/06 00:00:00","holiday":true,"working_minutes":240,"holiday_work_minutes":240,"late_night_minutes":30}
+			]
+		}
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, actual.StaffID)
+	assert.Len(t, actual.Attendances, 3)
+	assert.Equal(t, kiku.Minutes(540), actual.Attendances[0].WorkingMinutes)
+	assert.Equal(t, 19, actual.Attendances[0].WorkEndedAt.Hour())
+
+	assert.Equal(t, []kiku.MonthlyAttendance{
+		{
+			Year:            2000,
+			Month:           time.January,
+			WorkingDays:     1,
+			WorkingMinutes:  540,
+			BreakMinutes:    60,
+			OvertimeMinutes: 60,
+		},
+		{
+			Year:               2000,
+			Month:              time.February,
+			HolidayWorkDays:    1,
+			AbsenceDays:        1,
+			WorkingMinutes:     240,
+			LateNightMinutes:   30,
+			HolidayWorkMinutes: 240,
+		},
+	}, actual.Monthly())
+}
@@ -4,88 +4,280 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"log"
 	"net/http"
+	"time"
 )
 
-const endpointURL = "https://atnd.ak4.jp/api/cooperation"
+// DefaultBaseURL is the endpoint of AKASHI public API.
+const DefaultBaseURL = "https://atnd.ak4.jp/api/cooperation"
 
-type client struct {
-	c *http.Client
+// Client is the AKASHI API client.
+// A Client is safe for concurrent use by multiple goroutines.
+type Client struct {
+	baseURL          string
+	httpClient       *http.Client
+	loginCompanyCode string
+	token            string
+	userAgent        string
+	logger           Logger
+	retryPolicy      RetryPolicy
+	rateLimiter      *RateLimiter
+	tokenSource      TokenSource
+	tokenStore       TokenStore
+	middlewares      []Middleware
+	tracer           Tracer
+	meter            Meter
+	metrics          Metrics
 }
 
-func (c client) Get(ctx context.Context, url string) (response *http.Response, err error) {
-	reqURL := endpointURL + url
+// Option is the function that configures Client.
+type Option func(*Client)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
-	if err != nil {
-		return
+// WithBaseURL sets the base URL of AKASHI API.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
 	}
+}
 
-	log.Println(http.MethodGet, reqURL)
-	response, err = c.c.Do(req)
-	return
+// WithHTTPClient sets the HTTP client used for requests. A nil httpClient means a zero http.Client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithLoginCompanyCode sets the default AKASHI企業ID used when a parameter does not specify one.
+func WithLoginCompanyCode(code string) Option {
+	return func(c *Client) {
+		c.loginCompanyCode = code
+	}
 }
 
-func (c client) Post(ctx context.Context, url string, body interface{}) (response *http.Response, err error) {
-	reqURL := endpointURL + url
+// WithToken sets the default アクセストークン used when a parameter does not specify one.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
 
-	b, err := json.Marshal(body)
-	if err != nil {
-		return
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
 	}
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reqURL, bytes.NewBuffer(b))
-	if err != nil {
-		return
+// WithLogger sets the logger for request logging. A nil logger disables logging.
+// Access tokens in URLs and bodies are redacted before they are logged.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
 	}
-	req.Header.Set("Content-Type", "application/json")
+}
 
-	log.Println(http.MethodPost, reqURL)
-	response, err = c.c.Do(req)
-	return
+// WithRetryPolicy sets the policy for retrying failed requests.
+// GET requests are always eligible for retry, while POST stamps are retried only when
+// the context is marked by WithIdempotencyKey. A nil policy disables retries.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
 }
 
-func (c client) Patch(ctx context.Context, url string, body interface{}) (response *http.Response, err error) {
-	reqURL := endpointURL + url
+// WithRateLimiter sets the rate limiter that paces requests per AKASHI企業ID.
+// Requests wait for the limiter instead of being rejected, and 429 responses slow the limiter down.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
 
-	b, err := json.Marshal(body)
-	if err != nil {
-		return
+// WithTokenSource sets the source of the access token used when a parameter does not specify one.
+// It takes precedence over WithToken.
+func WithTokenSource(source TokenSource) Option {
+	return func(c *Client) {
+		c.tokenSource = source
 	}
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, reqURL, bytes.NewBuffer(b))
-	if err != nil {
-		return
+// WithTokenStore sets the store in which every reissued token is persisted before it is returned.
+// If the store fails, the reissued token is still returned along with *TokenSaveError,
+// and ReissueTokenSource keeps using it after logging the failure.
+func WithTokenStore(store TokenStore) Option {
+	return func(c *Client) {
+		c.tokenStore = store
 	}
-	req.Header.Set("Content-Type", "application/json")
+}
 
-	log.Println(http.MethodPatch, reqURL)
-	response, err = c.c.Do(req)
-	return
+// NewClient returns the AKASHI API client configured by opts.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:     DefaultBaseURL,
+		httpClient:  &http.Client{},
+		logger:      NewStdLogger(log.Default(), false),
+		retryPolicy: DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.logger == nil {
+		c.logger = nopLogger{}
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{}
+	}
+	c.httpClient = chain(c.httpClient, c.middlewares)
+	return c
 }
 
-func (c client) Delete(ctx context.Context, url string, body interface{}) (response *http.Response, err error) {
-	reqURL := endpointURL + url
+func (c *Client) companyCode(code string) string {
+	if code == "" {
+		return c.loginCompanyCode
+	}
+	return code
+}
 
-	b, err := json.Marshal(body)
-	if err != nil {
-		return
+func (c *Client) accessToken(token string) string {
+	if token == "" {
+		return c.token
 	}
+	return token
+}
+
+// request is the description of a request to AKASHI API.
+type request struct {
+	method      string
+	path        string
+	body        interface{}
+	companyCode string
+	retryable   bool
+}
+
+func (c *Client) get(ctx context.Context, companyCode, url string) (response *http.Response, err error) {
+	return c.do(ctx, request{method: http.MethodGet, path: url, companyCode: companyCode, retryable: true})
+}
+
+func (c *Client) post(ctx context.Context, companyCode, url string, body interface{}) (response *http.Response, err error) {
+	return c.do(ctx, request{method: http.MethodPost, path: url, body: body, companyCode: companyCode})
+}
+
+func (c *Client) put(ctx context.Context, companyCode, url string, body interface{}) (response *http.Response, err error) {
+	return c.do(ctx, request{method: http.MethodPut, path: url, body: body, companyCode: companyCode})
+}
+
+func (c *Client) patch(ctx context.Context, companyCode, url string, body interface{}) (response *http.Response, err error) {
+	return c.do(ctx, request{method: http.MethodPatch, path: url, body: body, companyCode: companyCode})
+}
+
+func (c *Client) delete(ctx context.Context, companyCode, url string, body interface{}) (response *http.Response, err error) {
+	return c.do(ctx, request{method: http.MethodDelete, path: url, body: body, companyCode: companyCode})
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, reqURL, bytes.NewBuffer(b))
-	if err != nil {
-		return
+// do sends the request, retrying it according to the retry policy when it is retryable.
+func (c *Client) do(ctx context.Context, r request) (response *http.Response, err error) {
+	reqURL := c.baseURL + r.path
+
+	var b []byte
+	if r.body != nil {
+		if b, err = json.Marshal(r.body); err != nil {
+			return
+		}
+	}
+	key, hasKey := idempotencyKeyFrom(ctx)
+	ctx = context.WithValue(ctx, requestIDSlotKey{}, new(string))
+	if r.body != nil {
+		c.logger.Debug("kiku: request body", "method", r.method, "path", redactURL(r.path), "body", redactJSON(b))
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	log.Println(http.MethodDelete, reqURL)
-	response, err = c.c.Do(req)
-	return
+	for attempt := 1; ; attempt++ {
+		var body io.Reader
+		if r.body != nil {
+			body = bytes.NewReader(b)
+		}
+		var req *http.Request
+		if req, err = http.NewRequestWithContext(ctx, r.method, reqURL, body); err != nil {
+			return nil, err
+		}
+		if r.body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if c.userAgent != "" {
+			req.Header.Set("User-Agent", c.userAgent)
+		}
+		if hasKey {
+			req.Header.Set("Idempotency-Key", key)
+		}
+
+		if c.rateLimiter != nil {
+			waitStart := time.Now()
+			err = c.rateLimiter.Wait(ctx, r.companyCode)
+			if c.metrics != nil {
+				c.metrics.ObserveRateLimitWait(r.companyCode, time.Since(waitStart))
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		start := time.Now()
+		response, err = c.httpClient.Do(req)
+		if err != nil {
+			err = redactError(err)
+		}
+		duration := time.Since(start)
+		c.logRequest(r, attempt, duration, response, err)
+		recordAttempt(ctx, response, err)
+		if c.metrics != nil {
+			statusCode := 0
+			if err == nil {
+				statusCode = response.StatusCode
+			}
+			c.metrics.ObserveRequest(r.method, endpointOf(r.path, r.companyCode), statusCode, duration, err)
+		}
+		if c.rateLimiter != nil && err == nil && response.StatusCode == http.StatusTooManyRequests {
+			d, _ := retryAfter(response)
+			c.rateLimiter.Throttle(r.companyCode, d)
+		}
+
+		if !r.retryable || c.retryPolicy == nil {
+			return
+		}
+		wait, retry := c.retryPolicy.Retry(attempt, response, err)
+		if !retry {
+			return
+		}
+		if response != nil {
+			io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+		c.logger.Warn("kiku: retrying request", "method", r.method, "path", redactURL(r.path), "attempt", attempt, "wait", wait)
+		if c.metrics != nil {
+			c.metrics.ObserveRetry(r.method, endpointOf(r.path, r.companyCode))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
 }
 
-func newClient() *client {
-	return &client{
-		c: &http.Client{},
+// logRequest logs the result of an attempt of r.
+func (c *Client) logRequest(r request, attempt int, duration time.Duration, res *http.Response, err error) {
+	args := []any{"method", r.method, "path", redactURL(r.path), "attempt", attempt, "duration", duration}
+	switch {
+	case err != nil:
+		c.logger.Error("kiku: request failed", append(args, "error", err)...)
+	case res.StatusCode >= http.StatusBadRequest:
+		c.logger.Warn("kiku: request", append(args, "status", res.StatusCode)...)
+	default:
+		c.logger.Info("kiku: request", append(args, "status", res.StatusCode)...)
 	}
 }
+
+var defaultClient = NewClient()
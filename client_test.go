@@ -0,0 +1,103 @@
+package kiku_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Client_GetStaff(t *testing.T) {
+	var (
+		path      string
+		userAgent string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.String()
+		userAgent = r.UserAgent()
+		io.WriteString(w, `{"success":true,"response":{"login_company_code":"foo","Count":0,"TotalCount":0,"staffs":[]}}`)
+	}))
+	defer ts.Close()
+
+	tests := map[string]struct {
+		opts   []kiku.Option
+		param  kiku.GetStaffParam
+		path   string
+		ua     string
+		expect kiku.GetStaffResponse
+	}{
+		"Parameters are given explicitly": {
+			opts: []kiku.Option{
+				kiku.WithBaseURL(ts.URL),
+				kiku.WithHTTPClient(ts.Client()),
+			},
+			param: kiku.GetStaffParam{
+				LoginCompanyCode: "foo",
+				Token:            "bar",
+			},
+			path:   "/foo/staffs?token=bar",
+			ua:     "Go-http-client/1.1",
+			expect: kiku.GetStaffResponse{LoginCompanyCode: "foo", Staffs: []kiku.Staff{}},
+		},
+		"Parameters are completed by options": {
+			opts: []kiku.Option{
+				kiku.WithBaseURL(ts.URL),
+				kiku.WithHTTPClient(ts.Client()),
+				kiku.WithLoginCompanyCode("foo"),
+				kiku.WithToken("baz"),
+				kiku.WithUserAgent("kiku-test"),
+				kiku.WithLogger(kiku.NewStdLogger(log.New(io.Discard, "", 0), true)),
+			},
+			param:  kiku.GetStaffParam{},
+			path:   "/foo/staffs?token=baz",
+			ua:     "kiku-test",
+			expect: kiku.GetStaffResponse{LoginCompanyCode: "foo", Staffs: []kiku.Staff{}},
+		},
+		"Nil HTTP client falls back to the default": {
+			opts: []kiku.Option{
+				kiku.WithBaseURL(ts.URL),
+				kiku.WithHTTPClient(nil),
+				kiku.WithMiddleware(kiku.UserAgent("kiku-test")),
+				kiku.WithLogger(nil),
+			},
+			param: kiku.GetStaffParam{
+				LoginCompanyCode: "foo",
+				Token:            "bar",
+			},
+			path:   "/foo/staffs?token=bar",
+			ua:     "kiku-test",
+			expect: kiku.GetStaffResponse{LoginCompanyCode: "foo", Staffs: []kiku.Staff{}},
+		},
+	}
+
+	for scenario, test := range tests {
+		cli := kiku.NewClient(test.opts...)
+		actual, err := cli.GetStaff(context.Background(), test.param)
+		assert.NoError(t, err, scenario)
+		assert.Equal(t, test.expect, actual, scenario)
+		assert.Equal(t, test.path, path, scenario)
+		assert.Equal(t, test.ua, userAgent, scenario)
+	}
+}
+
+func Test_Client_StatusCode(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+	)
+	_, err := cli.PostStamp(context.Background(), kiku.PostStampParam{})
+	assert.EqualError(t, err, "AKASHI API failed: POST /foo/stamps: status code=500")
+}
@@ -0,0 +1,224 @@
+package kiku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// StampCorrection is the struct representing a stamp correction application (打刻修正申請).
+type StampCorrection struct {
+	ID         int               `json:"application_id"` // 申請ID
+	StaffID    int               `json:"staff_id"`       // 申請者の従業員ID
+	TargetDate *AkTime           `json:"target_date"`    // 修正対象日
+	Type       StampType         `json:"type"`           // 修正後の打刻種別
+	StampedAt  *AkTime           `json:"stamped_at"`     // 修正後の打刻日時
+	Reason     string            `json:"reason"`         // 申請理由
+	Status     ApplicationStatus `json:"status"`         // 申請状態
+	AppliedAt  *AkTime           `json:"applied_at"`     // 申請日時
+	Comment    string            `json:"comment"`        // 承認者のコメント
+}
+
+// PostStampCorrectionParam is the struct for the request parameters of POST Stamp Correction API.
+type PostStampCorrectionParam struct {
+	LoginCompanyCode string    `json:"-"`           // AKASHI企業ID
+	Token            string    `json:"token"`       // アクセストークン
+	TargetDate       *AkTime   `json:"target_date"` // 修正対象日
+	Type             StampType `json:"type"`        // 修正後の打刻種別
+	StampedAt        *AkTime   `json:"stamped_at"`  // 修正後の打刻日時
+	Reason           string    `json:"reason"`      // 申請理由
+}
+
+// IsValid is the function to verify PostStampCorrectionParam is correct.
+func (p PostStampCorrectionParam) IsValid() (err error) {
+	switch {
+	case p.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case p.Token == "":
+		err = errors.New("Token must be set")
+	case p.TargetDate == nil:
+		err = errors.New("TargetDate must be set")
+	case p.Type == StampTypeUnknown:
+		err = errors.New("Type must be set")
+	case p.StampedAt == nil:
+		err = errors.New("StampedAt must be set")
+	case p.Reason == "":
+		err = errors.New("Reason must be set")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of POST Stamp Correction API.
+func (p PostStampCorrectionParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/applications/stamp_corrections", p.LoginCompanyCode)
+	return
+}
+
+// StampCorrectionResponse is the struct representing the response of submitting, approving or rejecting a stamp correction.
+type StampCorrectionResponse struct {
+	LoginCompanyCode string          `json:"login_company_code"` // AKASHI企業ID
+	Application      StampCorrection `json:"application"`        // 打刻修正申請
+}
+
+// Decode is the function that decodes StampCorrectionResponse.
+func (p *StampCorrectionResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, p)
+}
+
+// PostStampCorrection is the function that submits a stamp correction application to AKASHI.
+func PostStampCorrection(ctx context.Context, param PostStampCorrectionParam) (response StampCorrectionResponse, err error) {
+	return defaultClient.PostStampCorrection(ctx, param)
+}
+
+// PostStampCorrection is the method that submits a stamp correction application to AKASHI.
+func (c *Client) PostStampCorrection(ctx context.Context, param PostStampCorrectionParam) (response StampCorrectionResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "PostStampCorrection", param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.post(ctx, param.LoginCompanyCode, param.EncodeURL(), param)
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
+
+// GetStampCorrectionsParam is the struct for the request parameters of GET Stamp Correction API.
+type GetStampCorrectionsParam struct {
+	LoginCompanyCode string             // AKASHI企業ID
+	Token            string             // アクセストークン
+	StaffID          *int               // 絞り込む申請者の従業員ID
+	Status           *ApplicationStatus // 絞り込む申請状態
+}
+
+// IsValid is the function to verify GetStampCorrectionsParam is correct.
+func (g GetStampCorrectionsParam) IsValid() (err error) {
+	switch {
+	case g.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case g.Token == "":
+		err = errors.New("Token must be set")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of GET Stamp Correction API.
+func (g GetStampCorrectionsParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/applications/stamp_corrections", g.LoginCompanyCode)
+
+	uv := url.Values{}
+	uv.Add("token", g.Token)
+	if g.StaffID != nil {
+		uv.Add("staff_id", strconv.Itoa(*g.StaffID))
+	}
+	if g.Status != nil {
+		uv.Add("status", strconv.Itoa(int(*g.Status)))
+	}
+	encodedURL += "?" + uv.Encode()
+	return
+}
+
+// GetStampCorrectionsResponse is the struct representing the response of GET Stamp Correction API.
+type GetStampCorrectionsResponse struct {
+	LoginCompanyCode string            `json:"login_company_code"` // AKASHI企業ID
+	Count            int               `json:"count"`              // 打刻修正申請数
+	Applications     []StampCorrection `json:"applications"`       // 打刻修正申請の配列
+}
+
+// Decode is the function that decodes the response of GET Stamp Correction API.
+func (g *GetStampCorrectionsResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, g)
+}
+
+// GetStampCorrections is the function that retrieves stamp correction applications from AKASHI.
+func GetStampCorrections(ctx context.Context, param GetStampCorrectionsParam) (response GetStampCorrectionsResponse, err error) {
+	return defaultClient.GetStampCorrections(ctx, param)
+}
+
+// GetStampCorrections is the method that retrieves stamp correction applications from AKASHI.
+func (c *Client) GetStampCorrections(ctx context.Context, param GetStampCorrectionsParam) (response GetStampCorrectionsResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "GetStampCorrections", param.LoginCompanyCode, intValue(param.StaffID))
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.get(ctx, param.LoginCompanyCode, param.EncodeURL())
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
+
+// GetPendingStampCorrections is the function that retrieves stamp correction applications waiting for approval.
+func GetPendingStampCorrections(ctx context.Context, param GetStampCorrectionsParam) (response GetStampCorrectionsResponse, err error) {
+	return defaultClient.GetPendingStampCorrections(ctx, param)
+}
+
+// GetPendingStampCorrections is the method that retrieves stamp correction applications waiting for approval.
+func (c *Client) GetPendingStampCorrections(ctx context.Context, param GetStampCorrectionsParam) (response GetStampCorrectionsResponse, err error) {
+	status := ApplicationStatusPending
+	param.Status = &status
+	return c.GetStampCorrections(ctx, param)
+}
+
+// ApproveStampCorrection is the function that approves a stamp correction application as a manager.
+func ApproveStampCorrection(ctx context.Context, param ApplicationDecisionParam) (response StampCorrectionResponse, err error) {
+	return defaultClient.ApproveStampCorrection(ctx, param)
+}
+
+// ApproveStampCorrection is the method that approves a stamp correction application as a manager.
+func (c *Client) ApproveStampCorrection(ctx context.Context, param ApplicationDecisionParam) (response StampCorrectionResponse, err error) {
+	return c.decideStampCorrection(ctx, param, decisionApprove)
+}
+
+// RejectStampCorrection is the function that rejects a stamp correction application as a manager.
+func RejectStampCorrection(ctx context.Context, param ApplicationDecisionParam) (response StampCorrectionResponse, err error) {
+	return defaultClient.RejectStampCorrection(ctx, param)
+}
+
+// RejectStampCorrection is the method that rejects a stamp correction application as a manager.
+func (c *Client) RejectStampCorrection(ctx context.Context, param ApplicationDecisionParam) (response StampCorrectionResponse, err error) {
+	return c.decideStampCorrection(ctx, param, decisionReject)
+}
+
+func (c *Client) decideStampCorrection(ctx context.Context, param ApplicationDecisionParam, decision string) (response StampCorrectionResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	op := "ApproveStampCorrection"
+	if decision == decisionReject {
+		op = "RejectStampCorrection"
+	}
+	ctx, call := c.startCall(ctx, op, param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.post(ctx, param.LoginCompanyCode, param.encodeURL("stamp_corrections", decision), param)
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
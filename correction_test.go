@@ -0,0 +1,127 @@
+package kiku_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PostStampCorrectionParam_IsValid(t *testing.T) {
+	date := kiku.AkTime{Time: time.Date(2000, time.January, 2, 0, 0, 0, 0, time.UTC)}
+	stampedAt := kiku.AkTime{Time: time.Date(2000, time.January, 2, 9, 0, 0, 0, time.UTC)}
+	valid := kiku.PostStampCorrectionParam{
+		LoginCompanyCode: "foo",
+		Token:            "bar",
+		TargetDate:       &date,
+		Type:             kiku.StampTypeGoToWork,
+		StampedAt:        &stampedAt,
+		Reason:           "打刻忘れ",
+	}
+	without := func(f func(p *kiku.PostStampCorrectionParam)) kiku.PostStampCorrectionParam {
+		p := valid
+		f(&p)
+		return p
+	}
+	tests := map[string]struct {
+		p   kiku.PostStampCorrectionParam
+		err error
+	}{
+		"Necessary parameter set": {
+			p: valid,
+		},
+		"TargetDate is not set": {
+			p:   without(func(p *kiku.PostStampCorrectionParam) { p.TargetDate = nil }),
+			err: errors.New("TargetDate must be set"),
+		},
+		"Type is not set": {
+			p:   without(func(p *kiku.PostStampCorrectionParam) { p.Type = kiku.StampTypeUnknown }),
+			err: errors.New("Type must be set"),
+		},
+		"StampedAt is not set": {
+			p:   without(func(p *kiku.PostStampCorrectionParam) { p.StampedAt = nil }),
+			err: errors.New("StampedAt must be set"),
+		},
+		"Reason is not set": {
+			p:   without(func(p *kiku.PostStampCorrectionParam) { p.Reason = "" }),
+			err: errors.New("Reason must be set"),
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.err, test.p.IsValid(), scenario)
+	}
+}
+
+func Test_ApplicationDecisionParam_IsValid(t *testing.T) {
+	tests := map[string]struct {
+		p   kiku.ApplicationDecisionParam
+		err error
+	}{
+		"Necessary parameter set": {
+			p: kiku.ApplicationDecisionParam{LoginCompanyCode: "foo", Token: "bar", ApplicationID: 1},
+		},
+		"ApplicationID is not set": {
+			p:   kiku.ApplicationDecisionParam{LoginCompanyCode: "foo", Token: "bar"},
+			err: errors.New("ApplicationID must be set"),
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.err, test.p.IsValid(), scenario)
+	}
+}
+
+func Test_Client_StampCorrectionWorkflow(t *testing.T) {
+	var (
+		method string
+		path   string
+		query  string
+		body   string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.RawQuery
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		if r.Method == http.MethodGet {
+			io.WriteString(w, `{"success":true,"response":{"count":1,"applications":[{"application_id":1,"staff_id":2,"type":11,"status":1}]}}`)
+			return
+		}
+		io.WriteString(w, `{"success":true,"response":{"application":{"application_id":1,"staff_id":2,"type":11,"status":2,"comment":"OK"}}}`)
+	}))
+	defer ts.Close()
+
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+	)
+	ctx := context.Background()
+
+	pending, err := cli.GetPendingStampCorrections(ctx, kiku.GetStampCorrectionsParam{})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodGet, method)
+	assert.Equal(t, "/foo/applications/stamp_corrections", path)
+	assert.Equal(t, "status=1&token=bar", query)
+	assert.Equal(t, []kiku.StampCorrection{{ID: 1, StaffID: 2, Type: kiku.StampTypeGoToWork, Status: kiku.ApplicationStatusPending}}, pending.Applications)
+
+	approved, err := cli.ApproveStampCorrection(ctx, kiku.ApplicationDecisionParam{ApplicationID: 1, Comment: "OK"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/foo/applications/stamp_corrections/1/approve", path)
+	assert.JSONEq(t, `{"token":"bar","comment":"OK"}`, body)
+	assert.Equal(t, kiku.ApplicationStatusApproved, approved.Application.Status)
+
+	_, err = cli.RejectStampCorrection(ctx, kiku.ApplicationDecisionParam{ApplicationID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "/foo/applications/stamp_corrections/1/reject", path)
+	assert.JSONEq(t, `{"token":"bar"}`, body)
+}
@@ -0,0 +1,72 @@
+package kiku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// GetEmploymentCategoriesParam is the struct for the request parameters of GET Employment Category API.
+type GetEmploymentCategoriesParam struct {
+	LoginCompanyCode string // AKASHI企業ID
+	Token            string // アクセストークン
+}
+
+// IsValid is the function to verify GetEmploymentCategoriesParam is correct.
+func (g GetEmploymentCategoriesParam) IsValid() (err error) {
+	switch {
+	case g.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case g.Token == "":
+		err = errors.New("Token must be set")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of GET Employment Category API.
+func (g GetEmploymentCategoriesParam) EncodeURL() (encodedURL string) {
+	uv := url.Values{}
+	uv.Add("token", g.Token)
+	encodedURL = fmt.Sprintf("/%s/employment_categories?%s", g.LoginCompanyCode, uv.Encode())
+	return
+}
+
+// GetEmploymentCategoriesResponse is the struct representing the response of GET Employment Category API.
+type GetEmploymentCategoriesResponse struct {
+	LoginCompanyCode     string               `json:"login_company_code"`    // AKASHI企業ID
+	Count                int                  `json:"count"`                 // 雇用区分数
+	EmploymentCategories []EmploymentCategory `json:"employment_categories"` // 雇用区分の配列
+}
+
+// Decode is the function that decodes the response of GET Employment Category API.
+func (g *GetEmploymentCategoriesResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, g)
+}
+
+// GetEmploymentCategories is the function that retrieves the employment categories from AKASHI.
+func GetEmploymentCategories(ctx context.Context, param GetEmploymentCategoriesParam) (response GetEmploymentCategoriesResponse, err error) {
+	return defaultClient.GetEmploymentCategories(ctx, param)
+}
+
+// GetEmploymentCategories is the method that retrieves the employment categories from AKASHI.
+func (c *Client) GetEmploymentCategories(ctx context.Context, param GetEmploymentCategoriesParam) (response GetEmploymentCategoriesResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "GetEmploymentCategories", param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.get(ctx, param.LoginCompanyCode, param.EncodeURL())
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
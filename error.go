@@ -1,7 +1,90 @@
 package kiku
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
 // Error 失敗の原因となったエラーオブジェクト
 type Error struct {
 	Code    string `json:"code"`    // エラーコード
 	Message string `json:"message"` // エラーメッセージ
 }
+
+const (
+	// ErrorCodeUnauthorized エラーコード:認証エラー
+	ErrorCodeUnauthorized = "UNAUTHORIZED"
+	// ErrorCodeTokenExpired エラーコード:アクセストークンの有効期限切れ
+	ErrorCodeTokenExpired = "TOKEN_EXPIRED"
+	// ErrorCodeNotFound エラーコード:対象が存在しない
+	ErrorCodeNotFound = "NOT_FOUND"
+	// ErrorCodeTooManyRequests エラーコード:リクエスト数超過
+	ErrorCodeTooManyRequests = "TOO_MANY_REQUESTS"
+)
+
+var (
+	// ErrUnauthorized is reported when the access token is rejected.
+	ErrUnauthorized = errors.New("kiku: unauthorized")
+	// ErrTokenExpired is reported when the access token has expired.
+	ErrTokenExpired = errors.New("kiku: token expired")
+	// ErrRateLimited is reported when AKASHI throttles the request.
+	ErrRateLimited = errors.New("kiku: rate limited")
+	// ErrNotFound is reported when the requested resource does not exist.
+	ErrNotFound = errors.New("kiku: not found")
+)
+
+// APIError is the error representing a failed AKASHI API request.
+// It matches ErrUnauthorized, ErrTokenExpired, ErrRateLimited and ErrNotFound with errors.Is.
+type APIError struct {
+	StatusCode int     // HTTPステータスコード
+	Method     string  // リクエストメソッド
+	Path       string  // リクエストパス
+	Errors     []Error // AKASHIから返却されたエラーの配列
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString("AKASHI API failed")
+	if e.Method != "" {
+		fmt.Fprintf(&b, ": %s %s", e.Method, e.Path)
+	}
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, ": status code=%d", e.StatusCode)
+	}
+	for i, ae := range e.Errors {
+		sep := ", "
+		if i == 0 {
+			sep = ": "
+		}
+		fmt.Fprintf(&b, "%s%s (%s)", sep, ae.Message, ae.Code)
+	}
+	return b.String()
+}
+
+// Is reports whether the error matches target.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.HasCode(ErrorCodeUnauthorized) || e.HasCode(ErrorCodeTokenExpired)
+	case ErrTokenExpired:
+		return e.HasCode(ErrorCodeTokenExpired)
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests || e.HasCode(ErrorCodeTooManyRequests)
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.HasCode(ErrorCodeNotFound)
+	default:
+		return false
+	}
+}
+
+// HasCode reports whether AKASHI returned the error code.
+func (e *APIError) HasCode(code string) bool {
+	for _, ae := range e.Errors {
+		if ae.Code == code {
+			return true
+		}
+	}
+	return false
+}
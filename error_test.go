@@ -0,0 +1,152 @@
+package kiku_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_APIError_Error(t *testing.T) {
+	tests := map[string]struct {
+		e      *kiku.APIError
+		expect string
+	}{
+		"Without request information": {
+			e:      &kiku.APIError{},
+			expect: "AKASHI API failed",
+		},
+		"With request information and errors": {
+			e: &kiku.APIError{
+				StatusCode: http.StatusBadRequest,
+				Method:     http.MethodGet,
+				Path:       "/foo/staffs",
+				Errors: []kiku.Error{
+					{Code: "A", Message: "foo"},
+					{Code: "B", Message: "bar"},
+				},
+			},
+			expect: "AKASHI API failed: GET /foo/staffs: status code=400: foo (A), bar (B)",
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.expect, test.e.Error(), scenario)
+	}
+}
+
+func Test_APIError_Is(t *testing.T) {
+	tests := map[string]struct {
+		e      error
+		target error
+		expect bool
+	}{
+		"401 is ErrUnauthorized": {
+			e:      &kiku.APIError{StatusCode: http.StatusUnauthorized},
+			target: kiku.ErrUnauthorized,
+			expect: true,
+		},
+		"Token expired is ErrUnauthorized": {
+			e:      &kiku.APIError{Errors: []kiku.Error{{Code: kiku.ErrorCodeTokenExpired}}},
+			target: kiku.ErrUnauthorized,
+			expect: true,
+		},
+		"Token expired is ErrTokenExpired": {
+			e:      &kiku.APIError{StatusCode: http.StatusUnauthorized, Errors: []kiku.Error{{Code: kiku.ErrorCodeTokenExpired}}},
+			target: kiku.ErrTokenExpired,
+			expect: true,
+		},
+		"401 without code is not ErrTokenExpired": {
+			e:      &kiku.APIError{StatusCode: http.StatusUnauthorized},
+			target: kiku.ErrTokenExpired,
+			expect: false,
+		},
+		"429 is ErrRateLimited": {
+			e:      &kiku.APIError{StatusCode: http.StatusTooManyRequests},
+			target: kiku.ErrRateLimited,
+			expect: true,
+		},
+		"404 is ErrNotFound": {
+			e:      &kiku.APIError{StatusCode: http.StatusNotFound},
+			target: kiku.ErrNotFound,
+			expect: true,
+		},
+		"500 is not ErrNotFound": {
+			e:      &kiku.APIError{StatusCode: http.StatusInternalServerError},
+			target: kiku.ErrNotFound,
+			expect: false,
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.expect, errors.Is(test.e, test.target), scenario)
+	}
+}
+
+func Test_Client_APIError(t *testing.T) {
+	tests := map[string]struct {
+		status int
+		body   string
+		expect *kiku.APIError
+	}{
+		"success is false": {
+			status: http.StatusOK,
+			body:   `{"success":false,"errors":[{"code":"TOKEN_EXPIRED","message":"expired"}]}`,
+			expect: &kiku.APIError{
+				StatusCode: http.StatusOK,
+				Method:     http.MethodGet,
+				Path:       "/foo/stamps",
+				Errors:     []kiku.Error{{Code: "TOKEN_EXPIRED", Message: "expired"}},
+			},
+		},
+		"Status code is not 200": {
+			status: http.StatusUnauthorized,
+			body:   `{"success":false,"errors":[{"code":"UNAUTHORIZED","message":"invalid"}]}`,
+			expect: &kiku.APIError{
+				StatusCode: http.StatusUnauthorized,
+				Method:     http.MethodGet,
+				Path:       "/foo/stamps",
+				Errors:     []kiku.Error{{Code: "UNAUTHORIZED", Message: "invalid"}},
+			},
+		},
+		"Body is not JSON": {
+			status: http.StatusBadGateway,
+			body:   `<html></html>`,
+			expect: &kiku.APIError{
+				StatusCode: http.StatusBadGateway,
+				Method:     http.MethodGet,
+				Path:       "/foo/stamps",
+			},
+		},
+	}
+
+	for scenario, test := range tests {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.status)
+			io.WriteString(w, test.body)
+		}))
+		cli := kiku.NewClient(
+			kiku.WithBaseURL(ts.URL),
+			kiku.WithHTTPClient(ts.Client()),
+			kiku.WithLogger(nil),
+			kiku.WithRetryPolicy(nil),
+		)
+		_, err := cli.GetStamps(context.Background(), kiku.GetStampParam{
+			LoginCompanyCode: "foo",
+			Token:            "bar",
+			StartDate:        &time.Time{},
+			EndDate:          &time.Time{},
+		})
+		ts.Close()
+
+		var actual *kiku.APIError
+		assert.True(t, errors.As(err, &actual), scenario)
+		assert.Equal(t, test.expect, actual, scenario)
+	}
+}
@@ -0,0 +1,474 @@
+// Package kikutest provides an in-process fake AKASHI server for testing code which uses kiku.
+//
+// The server implements the staff, stamps and token reissue endpoints on an in-memory dataset,
+// and can inject faults, simulate token expiry and record every request.
+//
+//	srv := kikutest.NewServer("foo")
+//	defer srv.Close()
+//	srv.AddStaff(kiku.Staff{ID: 1, LastName: "山田"})
+//	cli := srv.Client(kiku.WithToken(srv.IssueToken(1, time.Hour)))
+package kikutest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hapoon/kiku"
+)
+
+const (
+	// DefaultPageSize is the number of employees in a page of GET Employee API.
+	DefaultPageSize = 100
+	// DefaultTokenTTL is the lifetime of tokens issued by PostTokenReissue.
+	DefaultTokenTTL = 24 * time.Hour
+)
+
+// Request is the struct representing a request received by Server.
+type Request struct {
+	Method string      // HTTPメソッド
+	Path   string      // クエリを除いたパス
+	Query  url.Values  // クエリパラメータ
+	Header http.Header // ヘッダ
+	Body   []byte      // リクエストボディ
+	Token  string      // クエリまたはボディのアクセストークン
+}
+
+// Fault is the struct representing an error injected into the responses of Server.
+type Fault struct {
+	Method     string        // 対象のHTTPメソッド(空の場合はすべて)
+	Path       string        // 対象のパスの前方一致(空の場合はすべて)
+	Times      int           // 注入する回数(0の場合は無制限)
+	Latency    time.Duration // 応答前の遅延
+	StatusCode int           // 応答するステータスコード(RetryAfterのみ指定した場合は429)
+	RetryAfter time.Duration // Retry-Afterヘッダの秒数
+	Errors     []kiku.Error  // success:falseで応答するエラー
+	Malformed  bool          // 不正なJSONで応答するか
+}
+
+func (f Fault) matches(r *http.Request) bool {
+	return (f.Method == "" || f.Method == r.Method) && strings.HasPrefix(r.URL.Path, f.Path)
+}
+
+// fails reports whether the fault replaces the response, rather than only delaying it.
+func (f Fault) fails() bool {
+	return f.StatusCode != 0 || f.RetryAfter > 0 || len(f.Errors) > 0 || f.Malformed
+}
+
+type token struct {
+	staffID   int
+	expiredAt time.Time
+}
+
+// Server is the fake AKASHI server for a company.
+// The dataset and the faults can be modified while the server is running.
+type Server struct {
+	*httptest.Server
+	CompanyCode string        // AKASHI企業ID
+	PageSize    int           // 従業員取得APIの1ページあたりの件数
+	TokenTTL    time.Duration // 再発行したトークンの有効期間
+
+	mu       sync.Mutex
+	staffs   map[int]kiku.Staff
+	stamps   map[int][]kiku.Stamp
+	tokens   map[string]token
+	faults   []*Fault
+	requests []Request
+	issued   int
+}
+
+// NewServer starts the fake server for companyCode. The caller should call Close when finished.
+func NewServer(companyCode string) *Server {
+	s := &Server{
+		CompanyCode: companyCode,
+		PageSize:    DefaultPageSize,
+		TokenTTL:    DefaultTokenTTL,
+		staffs:      map[int]kiku.Staff{},
+		stamps:      map[int][]kiku.Stamp{},
+		tokens:      map[string]token{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns the kiku client talking to the server, configured further by opts.
+func (s *Server) Client(opts ...kiku.Option) *kiku.Client {
+	return kiku.NewClient(append([]kiku.Option{
+		kiku.WithBaseURL(s.URL),
+		kiku.WithHTTPClient(s.Server.Client()),
+		kiku.WithLoginCompanyCode(s.CompanyCode),
+		kiku.WithLogger(nil),
+	}, opts...)...)
+}
+
+// AddStaff adds or replaces the employees.
+func (s *Server) AddStaff(staffs ...kiku.Staff) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, staff := range staffs {
+		s.staffs[staff.ID] = staff
+	}
+}
+
+// AddStamps adds the stamps of the employee staffID.
+func (s *Server) AddStamps(staffID int, stamps ...kiku.Stamp) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stamps[staffID] = append(s.stamps[staffID], stamps...)
+}
+
+// Stamps returns the stamps of the employee staffID, including those registered by POST stamp API.
+func (s *Server) Stamps(staffID int) []kiku.Stamp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]kiku.Stamp(nil), s.stamps[staffID]...)
+}
+
+// IssueToken issues the token of the employee staffID which expires after ttl.
+func (s *Server) IssueToken(staffID int, ttl time.Duration) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.issue(staffID, ttl)
+}
+
+func (s *Server) issue(staffID int, ttl time.Duration) string {
+	s.issued++
+	t := fmt.Sprintf("token-%d-%d", staffID, s.issued)
+	s.tokens[t] = token{staffID: staffID, expiredAt: time.Now().Add(ttl)}
+	return t
+}
+
+// ExpireToken makes the token expired, so that requests with it fail with kiku.ErrorCodeTokenExpired
+// until it is reissued.
+func (s *Server) ExpireToken(t string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if tk, ok := s.tokens[t]; ok {
+		tk.expiredAt = time.Now()
+		s.tokens[t] = tk
+	}
+}
+
+// Inject adds the fault. Faults are tried in the order they are added.
+func (s *Server) Inject(faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range faults {
+		f := faults[i]
+		s.faults = append(s.faults, &f)
+	}
+}
+
+// ClearFaults removes all the faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the requests received so far in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// ClearRequests forgets the requests received so far.
+func (s *Server) ClearRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	req := Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+		Token:  r.URL.Query().Get("token"),
+	}
+	if req.Token == "" && len(body) > 0 {
+		var b struct {
+			Token string `json:"token"`
+		}
+		json.Unmarshal(body, &b)
+		req.Token = b.Token
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	fault, found := s.fault(r)
+	s.mu.Unlock()
+
+	if found {
+		if fault.Latency > 0 {
+			timer := time.NewTimer(fault.Latency)
+			select {
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+		if fault.fails() {
+			writeFault(w, fault)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.route(w, req)
+}
+
+// fault returns the first fault matching r and consumes it.
+func (s *Server) fault(r *http.Request) (fault Fault, found bool) {
+	for i, f := range s.faults {
+		if !f.matches(r) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return *f, true
+	}
+	return
+}
+
+func writeFault(w http.ResponseWriter, f Fault) {
+	if f.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Seconds())))
+	}
+	status := f.StatusCode
+	switch {
+	case status != 0:
+	case f.RetryAfter > 0:
+		status = http.StatusTooManyRequests
+	default:
+		status = http.StatusOK
+	}
+	if f.Malformed {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		io.WriteString(w, `{"success":true,"response":`)
+		return
+	}
+
+	errs := f.Errors
+	if len(errs) == 0 {
+		code := strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+		if status == http.StatusTooManyRequests {
+			code = kiku.ErrorCodeTooManyRequests
+		}
+		errs = []kiku.Error{{Code: code, Message: http.StatusText(status)}}
+	}
+	writeErrors(w, status, errs...)
+}
+
+func (s *Server) route(w http.ResponseWriter, req Request) {
+	segments := strings.Split(strings.Trim(req.Path, "/"), "/")
+	switch {
+	case len(segments) == 3 && segments[0] == "token" && segments[1] == "reissue" && req.Method == http.MethodPost:
+		if segments[2] != s.CompanyCode {
+			break
+		}
+		s.reissue(w, req)
+		return
+	case len(segments) < 2 || segments[0] != s.CompanyCode:
+	case segments[1] == "staffs" && req.Method == http.MethodGet && len(segments) <= 3:
+		if staffID, ok := s.authorize(w, req); ok {
+			s.getStaff(w, req, staffID, segments[2:])
+		}
+		return
+	case segments[1] == "stamps" && req.Method == http.MethodGet && len(segments) <= 3:
+		if staffID, ok := s.authorize(w, req); ok {
+			s.getStamps(w, req, staffID, segments[2:])
+		}
+		return
+	case segments[1] == "stamps" && req.Method == http.MethodPost && len(segments) == 2:
+		if staffID, ok := s.authorize(w, req); ok {
+			s.postStamp(w, req, staffID)
+		}
+		return
+	}
+	writeErrors(w, http.StatusNotFound, kiku.Error{Code: kiku.ErrorCodeNotFound, Message: "endpoint not found"})
+}
+
+// authorize returns the employee of the token of req.
+func (s *Server) authorize(w http.ResponseWriter, req Request) (staffID int, ok bool) {
+	t, found := s.tokens[req.Token]
+	switch {
+	case !found:
+		writeErrors(w, http.StatusUnauthorized, kiku.Error{Code: kiku.ErrorCodeUnauthorized, Message: "invalid token"})
+	case !time.Now().Before(t.expiredAt):
+		writeErrors(w, http.StatusUnauthorized, kiku.Error{Code: kiku.ErrorCodeTokenExpired, Message: "token expired"})
+	default:
+		return t.staffID, true
+	}
+	return
+}
+
+// reissue replaces the token of req with a new one. An expired token can be reissued as well.
+func (s *Server) reissue(w http.ResponseWriter, req Request) {
+	old, found := s.tokens[req.Token]
+	if !found {
+		writeErrors(w, http.StatusUnauthorized, kiku.Error{Code: kiku.ErrorCodeUnauthorized, Message: "invalid token"})
+		return
+	}
+	delete(s.tokens, req.Token)
+	staffID := old.staffID
+	t := s.issue(staffID, s.TokenTTL)
+	expiredAt := kiku.AkTime{Time: s.tokens[t].expiredAt}
+	writeResponse(w, kiku.PostTokenReissueResponse{
+		LoginCompanyCode: s.CompanyCode,
+		StaffId:          staffID,
+		Token:            t,
+		ExpiredAt:        &expiredAt,
+	})
+}
+
+func (s *Server) getStaff(w http.ResponseWriter, req Request, staffID int, rest []string) {
+	var staffs []kiku.Staff
+	total := 1
+	switch {
+	case len(rest) == 1:
+		id, err := strconv.Atoi(rest[0])
+		staff, found := s.staffs[id]
+		if err != nil || !found {
+			writeErrors(w, http.StatusNotFound, kiku.Error{Code: kiku.ErrorCodeNotFound, Message: "staff not found"})
+			return
+		}
+		staffs = []kiku.Staff{staff}
+	case req.Query.Get("page") != "":
+		page, _ := strconv.Atoi(req.Query.Get("page"))
+		all := make([]kiku.Staff, 0, len(s.staffs))
+		for _, staff := range s.staffs {
+			all = append(all, staff)
+		}
+		sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+		total = len(all)
+		if start := (page - 1) * s.PageSize; page > 0 && start < total {
+			end := start + s.PageSize
+			if end > total {
+				end = total
+			}
+			staffs = all[start:end]
+		}
+	default:
+		staff, found := s.staffs[staffID]
+		if !found {
+			staff = kiku.Staff{ID: staffID}
+		}
+		staffs = []kiku.Staff{staff}
+	}
+
+	writeResponse(w, kiku.GetStaffResponse{
+		LoginCompanyCode: s.CompanyCode,
+		Count:            len(staffs),
+		TotalCount:       total,
+		Staffs:           staffs,
+	})
+}
+
+func (s *Server) getStamps(w http.ResponseWriter, req Request, staffID int, rest []string) {
+	if len(rest) == 1 {
+		id, err := strconv.Atoi(rest[0])
+		if err != nil {
+			writeErrors(w, http.StatusNotFound, kiku.Error{Code: kiku.ErrorCodeNotFound, Message: "staff not found"})
+			return
+		}
+		staffID = id
+	}
+
+	var start, end kiku.AkTime
+	for _, p := range []struct {
+		name string
+		t    *kiku.AkTime
+	}{{"start_date", &start}, {"end_date", &end}} {
+		if err := p.t.UnmarshalText([]byte(req.Query.Get(p.name))); err != nil {
+			writeErrors(w, http.StatusBadRequest, kiku.Error{Code: "INVALID_PARAMETER", Message: p.name + " is invalid"})
+			return
+		}
+	}
+
+	var stamps []kiku.Stamp
+	for _, stamp := range s.stamps[staffID] {
+		if stamp.StampedAt == nil || stamp.StampedAt.Before(start.Time) || stamp.StampedAt.After(end.Time) {
+			continue
+		}
+		stamps = append(stamps, stamp)
+	}
+	sort.SliceStable(stamps, func(i, j int) bool { return stamps[i].StampedAt.Before(stamps[j].StampedAt.Time) })
+
+	writeResponse(w, kiku.GetStampResponse{
+		LoginCompanyCode: s.CompanyCode,
+		StaffID:          staffID,
+		Count:            len(stamps),
+		Stamps:           stamps,
+	})
+}
+
+func (s *Server) postStamp(w http.ResponseWriter, req Request, staffID int) {
+	var param kiku.PostStampParam
+	if err := json.Unmarshal(req.Body, &param); err != nil {
+		writeErrors(w, http.StatusBadRequest, kiku.Error{Code: "INVALID_PARAMETER", Message: err.Error()})
+		return
+	}
+
+	stampedAt := kiku.AkTime{Time: time.Now().In(kiku.Location).Truncate(time.Second)}
+	stamp := kiku.Stamp{
+		StampedAt:  &stampedAt,
+		Type:       param.Type,
+		LocalTime:  param.StampedAt,
+		Timezone:   param.Timezone,
+		Attributes: kiku.StampAttribute{Method: kiku.StampMethodAPI},
+	}
+	s.stamps[staffID] = append(s.stamps[staffID], stamp)
+
+	writeResponse(w, kiku.PostStampResponse{
+		LoginCompanyCode: s.CompanyCode,
+		StaffID:          staffID,
+		Type:             param.Type,
+		StampedAt:        &stampedAt,
+	})
+}
+
+func writeResponse(w http.ResponseWriter, response interface{}) {
+	writeJSON(w, http.StatusOK, struct {
+		Success  bool        `json:"success"`
+		Response interface{} `json:"response"`
+	}{true, response})
+}
+
+func writeErrors(w http.ResponseWriter, status int, errs ...kiku.Error) {
+	writeJSON(w, status, struct {
+		Success bool         `json:"success"`
+		Errors  []kiku.Error `json:"errors"`
+	}{false, errs})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,248 @@
+package kikutest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/hapoon/kiku/kikutest"
+	"github.com/stretchr/testify/assert"
+)
+
+func stampAt(day, hour int, typ kiku.StampType) kiku.Stamp {
+	at := kiku.AkTime{Time: time.Date(2000, time.January, day, hour, 0, 0, 0, kiku.Location)}
+	return kiku.Stamp{StampedAt: &at, Type: typ}
+}
+
+func Test_Server_Staff(t *testing.T) {
+	srv := kikutest.NewServer("foo")
+	defer srv.Close()
+	srv.PageSize = 2
+	srv.AddStaff(kiku.Staff{ID: 3, LastName: "佐藤"}, kiku.Staff{ID: 1, LastName: "山田"}, kiku.Staff{ID: 2, LastName: "鈴木"})
+	cli := srv.Client(kiku.WithToken(srv.IssueToken(1, time.Hour)))
+	ctx := context.Background()
+
+	res, err := cli.GetStaff(ctx, kiku.GetStaffParam{})
+	assert.NoError(t, err)
+	assert.Equal(t, []kiku.Staff{{ID: 1, LastName: "山田"}}, res.Staffs)
+
+	staffID := 2
+	res, err = cli.GetStaff(ctx, kiku.GetStaffParam{StaffID: &staffID})
+	assert.NoError(t, err)
+	assert.Equal(t, []kiku.Staff{{ID: 2, LastName: "鈴木"}}, res.Staffs)
+
+	staffID = 4
+	_, err = cli.GetStaff(ctx, kiku.GetStaffParam{StaffID: &staffID})
+	assert.ErrorIs(t, err, kiku.ErrNotFound)
+
+	staffs, err := cli.ListAllStaff(ctx, kiku.GetStaffParam{})
+	assert.NoError(t, err)
+	assert.Len(t, staffs, 3)
+	for i, s := range staffs {
+		assert.Equal(t, i+1, s.ID)
+	}
+}
+
+func Test_Server_Stamps(t *testing.T) {
+	srv := kikutest.NewServer("foo")
+	defer srv.Close()
+	srv.AddStamps(1,
+		stampAt(2, 18, kiku.StampTypeLeaveWork),
+		stampAt(2, 9, kiku.StampTypeGoToWork),
+		stampAt(5, 9, kiku.StampTypeGoToWork),
+	)
+	cli := srv.Client(kiku.WithToken(srv.IssueToken(1, time.Hour)))
+	ctx := context.Background()
+
+	start := time.Date(2000, time.January, 1, 0, 0, 0, 0, kiku.Location)
+	end := time.Date(2000, time.January, 3, 0, 0, 0, 0, kiku.Location)
+	res, err := cli.GetStamps(ctx, kiku.GetStampParam{StartDate: &start, EndDate: &end, StaffID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.StaffID)
+	assert.Equal(t, 2, res.Count)
+	assert.Equal(t, []kiku.StampType{kiku.StampTypeGoToWork, kiku.StampTypeLeaveWork},
+		[]kiku.StampType{res.Stamps[0].Type, res.Stamps[1].Type})
+
+	// Dates in another time zone are queried as the same instants in JST.
+	start = time.Date(2000, time.January, 2, 0, 0, 0, 0, time.UTC)
+	end = time.Date(2000, time.January, 2, 2, 0, 0, 0, time.UTC)
+	res, err = cli.GetStamps(ctx, kiku.GetStampParam{StartDate: &start, EndDate: &end, StaffID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Count)
+
+	posted, err := cli.PostStamp(ctx, kiku.PostStampParam{Type: kiku.StampTypeBreak})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, posted.StaffID)
+	assert.Equal(t, kiku.StampTypeBreak, posted.Type)
+	stamps := srv.Stamps(1)
+	assert.Len(t, stamps, 4)
+	assert.Equal(t, kiku.StampMethodAPI, stamps[3].Attributes.Method)
+}
+
+func Test_Server_Token(t *testing.T) {
+	srv := kikutest.NewServer("foo")
+	defer srv.Close()
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		token func() string
+		err   error
+	}{
+		"Valid token":   {token: func() string { return srv.IssueToken(1, time.Hour) }},
+		"Unknown token": {token: func() string { return "unknown" }, err: kiku.ErrUnauthorized},
+		"Expired token": {
+			token: func() string {
+				token := srv.IssueToken(1, time.Hour)
+				srv.ExpireToken(token)
+				return token
+			},
+			err: kiku.ErrTokenExpired,
+		},
+	}
+	for scenario, test := range tests {
+		_, err := srv.Client().GetStaff(ctx, kiku.GetStaffParam{Token: test.token()})
+		if test.err == nil {
+			assert.NoError(t, err, scenario)
+		} else {
+			assert.ErrorIs(t, err, test.err, scenario)
+		}
+	}
+
+	// An expired token is reissued by the token source, and the old one is revoked.
+	token := srv.IssueToken(1, time.Hour)
+	source := kiku.NewReissueTokenSource(srv.Client(), "foo", token, time.Time{}, 0)
+	cli := srv.Client(kiku.WithTokenSource(source))
+	srv.ExpireToken(token)
+	_, err := cli.GetStaff(ctx, kiku.GetStaffParam{})
+	assert.NoError(t, err)
+	_, err = srv.Client().GetStaff(ctx, kiku.GetStaffParam{Token: token})
+	assert.ErrorIs(t, err, kiku.ErrUnauthorized)
+}
+
+func Test_Server_Inject(t *testing.T) {
+	ctx := context.Background()
+	tests := map[string]struct {
+		fault    kikutest.Fault
+		timeout  time.Duration
+		err      error
+		requests int
+	}{
+		"Server error is retried": {
+			fault:    kikutest.Fault{StatusCode: http.StatusInternalServerError, Times: 1},
+			requests: 2,
+		},
+		"Too many requests": {
+			fault:    kikutest.Fault{StatusCode: http.StatusTooManyRequests, Times: 1},
+			requests: 2,
+		},
+		"Error code": {
+			fault:    kikutest.Fault{Path: "/foo/staffs", Errors: []kiku.Error{{Code: kiku.ErrorCodeNotFound, Message: "not found"}}},
+			err:      kiku.ErrNotFound,
+			requests: 1,
+		},
+		"Malformed JSON": {
+			fault:    kikutest.Fault{Method: http.MethodGet, Malformed: true},
+			requests: 1,
+		},
+		"Latency": {
+			fault:    kikutest.Fault{Latency: time.Second},
+			timeout:  50 * time.Millisecond,
+			err:      context.DeadlineExceeded,
+			requests: 1,
+		},
+		"Unmatched fault": {
+			fault:    kikutest.Fault{Method: http.MethodPost, StatusCode: http.StatusInternalServerError},
+			requests: 1,
+		},
+	}
+
+	for scenario, test := range tests {
+		srv := kikutest.NewServer("foo")
+		srv.Inject(test.fault)
+		cli := srv.Client(
+			kiku.WithToken(srv.IssueToken(1, time.Hour)),
+			kiku.WithRetryPolicy(kiku.ExponentialBackoff{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		)
+
+		c := ctx
+		cancel := func() {}
+		if test.timeout > 0 {
+			c, cancel = context.WithTimeout(ctx, test.timeout)
+		}
+		_, err := cli.GetStaff(c, kiku.GetStaffParam{})
+		cancel()
+
+		switch {
+		case test.fault.Malformed:
+			assert.Error(t, err, scenario)
+		case test.err != nil:
+			assert.ErrorIs(t, err, test.err, scenario)
+		default:
+			assert.NoError(t, err, scenario)
+		}
+		assert.Len(t, srv.Requests(), test.requests, scenario)
+		srv.Close()
+	}
+}
+
+func Test_Server_Inject_RetryAfter(t *testing.T) {
+	tests := map[string]struct {
+		fault  kikutest.Fault
+		status int
+	}{
+		"RetryAfter only implies 429": {
+			fault:  kikutest.Fault{RetryAfter: 2 * time.Second},
+			status: http.StatusTooManyRequests,
+		},
+		"RetryAfter with latency implies 429": {
+			fault:  kikutest.Fault{RetryAfter: 2 * time.Second, Latency: time.Millisecond},
+			status: http.StatusTooManyRequests,
+		},
+		"RetryAfter with status code": {
+			fault:  kikutest.Fault{RetryAfter: 2 * time.Second, StatusCode: http.StatusServiceUnavailable},
+			status: http.StatusServiceUnavailable,
+		},
+	}
+
+	for scenario, test := range tests {
+		srv := kikutest.NewServer("foo")
+		srv.Inject(test.fault)
+		res, err := http.Get(srv.URL + "/foo/staffs")
+		if !assert.NoError(t, err, scenario) {
+			srv.Close()
+			continue
+		}
+		res.Body.Close()
+		srv.Close()
+		assert.Equal(t, test.status, res.StatusCode, scenario)
+		assert.Equal(t, "2", res.Header.Get("Retry-After"), scenario)
+	}
+}
+
+func Test_Server_Requests(t *testing.T) {
+	srv := kikutest.NewServer("foo")
+	defer srv.Close()
+	token := srv.IssueToken(1, time.Hour)
+	cli := srv.Client(kiku.WithToken(token))
+	ctx := context.Background()
+
+	_, err := cli.GetStaff(ctx, kiku.GetStaffParam{})
+	assert.NoError(t, err)
+	_, err = cli.PostStamp(ctx, kiku.PostStampParam{Type: kiku.StampTypeGoToWork})
+	assert.NoError(t, err)
+
+	requests := srv.Requests()
+	assert.Len(t, requests, 2)
+	assert.Equal(t, http.MethodGet, requests[0].Method)
+	assert.Equal(t, "/foo/staffs", requests[0].Path)
+	assert.Equal(t, token, requests[0].Token)
+	assert.Equal(t, http.MethodPost, requests[1].Method)
+	assert.Equal(t, "/foo/stamps", requests[1].Path)
+	assert.Equal(t, token, requests[1].Token)
+	assert.Contains(t, string(requests[1].Body), `"type":11`)
+
+	srv.ClearRequests()
+	assert.Empty(t, srv.Requests())
+}
@@ -0,0 +1,285 @@
+package kiku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// LeaveType is the integer represents the type of leave (休暇種別).
+type LeaveType int
+
+const (
+	// LeaveTypeUnknown 休暇種別:不明
+	LeaveTypeUnknown LeaveType = 0
+	// LeaveTypePaid 休暇種別:有給休暇(全日)
+	LeaveTypePaid LeaveType = 1
+	// LeaveTypePaidAM 休暇種別:午前半休
+	LeaveTypePaidAM LeaveType = 2
+	// LeaveTypePaidPM 休暇種別:午後半休
+	LeaveTypePaidPM LeaveType = 3
+	// LeaveTypeSpecial 休暇種別:特別休暇
+	LeaveTypeSpecial LeaveType = 4
+)
+
+func (l LeaveType) String() string {
+	switch l {
+	case LeaveTypePaid:
+		return "有給休暇"
+	case LeaveTypePaidAM:
+		return "午前半休"
+	case LeaveTypePaidPM:
+		return "午後半休"
+	case LeaveTypeSpecial:
+		return "特別休暇"
+	default:
+		return ""
+	}
+}
+
+// LeaveApplication is the struct representing a leave application (休暇申請).
+type LeaveApplication struct {
+	ID             int               `json:"application_id"`   // 申請ID
+	StaffID        int               `json:"staff_id"`         // 申請者の従業員ID
+	Type           LeaveType         `json:"type"`             // 休暇種別
+	SpecialLeaveID int               `json:"special_leave_id"` // 特別休暇ID
+	Date           *AkTime           `json:"date"`             // 休暇取得日
+	Reason         string            `json:"reason"`           // 申請理由
+	Status         ApplicationStatus `json:"status"`           // 申請状態
+	AppliedAt      *AkTime           `json:"applied_at"`       // 申請日時
+}
+
+// PostLeaveApplicationParam is the struct for the request parameters of POST Leave Application API.
+type PostLeaveApplicationParam struct {
+	LoginCompanyCode string    `json:"-"`                          // AKASHI企業ID
+	Token            string    `json:"token"`                      // アクセストークン
+	Type             LeaveType `json:"type"`                       // 休暇種別
+	SpecialLeaveID   int       `json:"special_leave_id,omitempty"` // 特別休暇ID(特別休暇の場合のみ)
+	Date             *AkTime   `json:"date"`                       // 休暇取得日
+	Reason           string    `json:"reason,omitempty"`           // 申請理由
+}
+
+// IsValid is the function to verify PostLeaveApplicationParam is correct.
+func (p PostLeaveApplicationParam) IsValid() (err error) {
+	switch {
+	case p.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case p.Token == "":
+		err = errors.New("Token must be set")
+	case p.Type == LeaveTypeUnknown:
+		err = errors.New("Type must be set")
+	case p.Type == LeaveTypeSpecial && p.SpecialLeaveID == 0:
+		err = errors.New("SpecialLeaveID must be set for special leave")
+	case p.Date == nil:
+		err = errors.New("Date must be set")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of POST Leave Application API.
+func (p PostLeaveApplicationParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/applications/leaves", p.LoginCompanyCode)
+	return
+}
+
+// PostLeaveApplicationResponse is the struct representing the response of POST Leave Application API.
+type PostLeaveApplicationResponse struct {
+	LoginCompanyCode string           `json:"login_company_code"` // AKASHI企業ID
+	Application      LeaveApplication `json:"application"`        // 登録された休暇申請
+}
+
+// Decode is the function that decodes the response of POST Leave Application API.
+func (p *PostLeaveApplicationResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, p)
+}
+
+// PostLeaveApplication is the function that submits a leave application to AKASHI.
+func PostLeaveApplication(ctx context.Context, param PostLeaveApplicationParam) (response PostLeaveApplicationResponse, err error) {
+	return defaultClient.PostLeaveApplication(ctx, param)
+}
+
+// PostLeaveApplication is the method that submits a leave application to AKASHI.
+func (c *Client) PostLeaveApplication(ctx context.Context, param PostLeaveApplicationParam) (response PostLeaveApplicationResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "PostLeaveApplication", param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.post(ctx, param.LoginCompanyCode, param.EncodeURL(), param)
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
+
+// GetLeaveApplicationsParam is the struct for the request parameters of GET Leave Application API.
+type GetLeaveApplicationsParam struct {
+	LoginCompanyCode string             // AKASHI企業ID
+	Token            string             // アクセストークン
+	StaffID          *int               // 絞り込む申請者の従業員ID
+	StartDate        *time.Time         // 絞り込む休暇取得日の開始日
+	EndDate          *time.Time         // 絞り込む休暇取得日の終了日
+	Status           *ApplicationStatus // 絞り込む申請状態
+}
+
+// IsValid is the function to verify GetLeaveApplicationsParam is correct.
+func (g GetLeaveApplicationsParam) IsValid() (err error) {
+	switch {
+	case g.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case g.Token == "":
+		err = errors.New("Token must be set")
+	case g.StartDate != nil && g.EndDate != nil && g.EndDate.Before(*g.StartDate):
+		err = errors.New("EndDate must not be before StartDate")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of GET Leave Application API.
+func (g GetLeaveApplicationsParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/applications/leaves", g.LoginCompanyCode)
+
+	uv := url.Values{}
+	uv.Add("token", g.Token)
+	if g.StaffID != nil {
+		uv.Add("staff_id", strconv.Itoa(*g.StaffID))
+	}
+	if g.StartDate != nil {
+		uv.Add("start_date", g.StartDate.In(Location).Format(DateFormat))
+	}
+	if g.EndDate != nil {
+		uv.Add("end_date", g.EndDate.In(Location).Format(DateFormat))
+	}
+	if g.Status != nil {
+		uv.Add("status", strconv.Itoa(int(*g.Status)))
+	}
+	encodedURL += "?" + uv.Encode()
+	return
+}
+
+// GetLeaveApplicationsResponse is the struct representing the response of GET Leave Application API.
+type GetLeaveApplicationsResponse struct {
+	LoginCompanyCode string             `json:"login_company_code"` // AKASHI企業ID
+	Count            int                `json:"count"`              // 休暇申請数
+	Applications     []LeaveApplication `json:"applications"`       // 休暇申請の配列
+}
+
+// Decode is the function that decodes the response of GET Leave Application API.
+func (g *GetLeaveApplicationsResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, g)
+}
+
+// GetLeaveApplications is the function that retrieves leave applications from AKASHI.
+func GetLeaveApplications(ctx context.Context, param GetLeaveApplicationsParam) (response GetLeaveApplicationsResponse, err error) {
+	return defaultClient.GetLeaveApplications(ctx, param)
+}
+
+// GetLeaveApplications is the method that retrieves leave applications from AKASHI.
+func (c *Client) GetLeaveApplications(ctx context.Context, param GetLeaveApplicationsParam) (response GetLeaveApplicationsResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "GetLeaveApplications", param.LoginCompanyCode, intValue(param.StaffID))
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.get(ctx, param.LoginCompanyCode, param.EncodeURL())
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
+
+// LeaveGrant is the struct representing a grant of paid leave (有給休暇の付与).
+type LeaveGrant struct {
+	GrantedAt     *AkTime `json:"granted_at"`     // 付与日
+	ExpiresAt     *AkTime `json:"expires_at"`     // 失効日
+	Days          float64 `json:"days"`           // 付与日数
+	RemainingDays float64 `json:"remaining_days"` // 残日数
+}
+
+// GetLeaveBalanceParam is the struct for the request parameters of GET Leave Balance API.
+type GetLeaveBalanceParam struct {
+	LoginCompanyCode string // AKASHI企業ID
+	Token            string // アクセストークン
+	StaffID          int    // 取得対象の従業員ID
+}
+
+// IsValid is the function to verify GetLeaveBalanceParam is correct.
+func (g GetLeaveBalanceParam) IsValid() (err error) {
+	switch {
+	case g.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case g.Token == "":
+		err = errors.New("Token must be set")
+	case g.StaffID == 0:
+		err = errors.New("StaffID must be set")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of GET Leave Balance API.
+func (g GetLeaveBalanceParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/leave_balances/%d", g.LoginCompanyCode, g.StaffID)
+
+	uv := url.Values{}
+	uv.Add("token", g.Token)
+	encodedURL += "?" + uv.Encode()
+	return
+}
+
+// GetLeaveBalanceResponse is the struct representing the response of GET Leave Balance API.
+type GetLeaveBalanceResponse struct {
+	LoginCompanyCode string       `json:"login_company_code"` // AKASHI企業ID
+	StaffID          int          `json:"staff_id"`           // 従業員ID
+	RemainingDays    float64      `json:"remaining_days"`     // 有給休暇の残日数
+	RemainingTime    Minutes      `json:"remaining_minutes"`  // 時間単位有給休暇の残時間
+	Grants           []LeaveGrant `json:"grants"`             // 付与の配列
+}
+
+// Decode is the function that decodes the response of GET Leave Balance API.
+func (g *GetLeaveBalanceResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, g)
+}
+
+// GetLeaveBalance is the function that retrieves the remaining paid leave of an employee from AKASHI.
+func GetLeaveBalance(ctx context.Context, param GetLeaveBalanceParam) (response GetLeaveBalanceResponse, err error) {
+	return defaultClient.GetLeaveBalance(ctx, param)
+}
+
+// GetLeaveBalance is the method that retrieves the remaining paid leave of an employee from AKASHI.
+func (c *Client) GetLeaveBalance(ctx context.Context, param GetLeaveBalanceParam) (response GetLeaveBalanceResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "GetLeaveBalance", param.LoginCompanyCode, param.StaffID)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.get(ctx, param.LoginCompanyCode, param.EncodeURL())
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
@@ -0,0 +1,132 @@
+package kiku_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PostLeaveApplicationParam_IsValid(t *testing.T) {
+	date := kiku.AkTime{Time: time.Date(2000, time.January, 2, 0, 0, 0, 0, time.UTC)}
+	tests := map[string]struct {
+		p   kiku.PostLeaveApplicationParam
+		err error
+	}{
+		"Paid leave": {
+			p: kiku.PostLeaveApplicationParam{LoginCompanyCode: "foo", Token: "bar", Type: kiku.LeaveTypePaid, Date: &date},
+		},
+		"Special leave": {
+			p: kiku.PostLeaveApplicationParam{LoginCompanyCode: "foo", Token: "bar", Type: kiku.LeaveTypeSpecial, SpecialLeaveID: 1, Date: &date},
+		},
+		"LoginCompanyCode is not set": {
+			p:   kiku.PostLeaveApplicationParam{Token: "bar", Type: kiku.LeaveTypePaid, Date: &date},
+			err: errors.New("LoginCompanyCode must be set"),
+		},
+		"Type is not set": {
+			p:   kiku.PostLeaveApplicationParam{LoginCompanyCode: "foo", Token: "bar", Date: &date},
+			err: errors.New("Type must be set"),
+		},
+		"SpecialLeaveID is not set": {
+			p:   kiku.PostLeaveApplicationParam{LoginCompanyCode: "foo", Token: "bar", Type: kiku.LeaveTypeSpecial, Date: &date},
+			err: errors.New("SpecialLeaveID must be set for special leave"),
+		},
+		"Date is not set": {
+			p:   kiku.PostLeaveApplicationParam{LoginCompanyCode: "foo", Token: "bar", Type: kiku.LeaveTypePaidAM},
+			err: errors.New("Date must be set"),
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.err, test.p.IsValid(), scenario)
+	}
+}
+
+func Test_GetLeaveApplicationsParam_EncodeURL(t *testing.T) {
+	staffID := 1
+	startDate := time.Date(2000, time.January, 1, 0, 0, 0, 0, kiku.Location)
+	endDate := time.Date(2000, time.January, 31, 0, 0, 0, 0, kiku.Location)
+	status := kiku.ApplicationStatusPending
+	tests := map[string]struct {
+		g      kiku.GetLeaveApplicationsParam
+		expect string
+	}{
+		"Minimum": {
+			g:      kiku.GetLeaveApplicationsParam{LoginCompanyCode: "foo", Token: "bar"},
+			expect: "/foo/applications/leaves?token=bar",
+		},
+		"All filters": {
+			g: kiku.GetLeaveApplicationsParam{
+				LoginCompanyCode: "foo",
+				Token:            "bar",
+				StaffID:          &staffID,
+				StartDate:        &startDate,
+				EndDate:          &endDate,
+				Status:           &status,
+			},
+			expect: "/foo/applications/leaves?end_date=20000131000000&staff_id=1&start_date=20000101000000&status=1&token=bar",
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.expect, test.g.EncodeURL(), scenario)
+	}
+}
+
+func Test_GetLeaveBalanceParam_IsValid(t *testing.T) {
+	tests := map[string]struct {
+		g   kiku.GetLeaveBalanceParam
+		err error
+	}{
+		"Normal scenario": {
+			g: kiku.GetLeaveBalanceParam{LoginCompanyCode: "foo", Token: "bar", StaffID: 1},
+		},
+		"StaffID is not set": {
+			g:   kiku.GetLeaveBalanceParam{LoginCompanyCode: "foo", Token: "bar"},
+			err: errors.New("StaffID must be set"),
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.err, test.g.IsValid(), scenario)
+	}
+}
+
+func Test_GetLeaveBalanceResponse_Decode(t *testing.T) {
+	grantedAt := kiku.AkTime{Time: time.Date(2000, time.April, 1, 0, 0, 0, 0, kiku.Location)}
+	expiresAt := kiku.AkTime{Time: time.Date(2002, time.March, 31, 0, 0, 0, 0, kiku.Location)}
+	tests := map[string]struct {
+		input  string
+		expect kiku.GetLeaveBalanceResponse
+		err    error
+	}{
+		"Success": {
+			input: `{"success":true,"response":{"login_company_code":"foo","staff_id":1,"remaining_days":7.5,"remaining_minutes":240,` +
+				`"grants":[{"granted_at":"2000/04/01 00:00:00","expires_at":"2002/03/31 00:00:00","days":10,"remaining_days":7.5}]}}`,
+			expect: kiku.GetLeaveBalanceResponse{
+				LoginCompanyCode: "foo",
+				StaffID:          1,
+				RemainingDays:    7.5,
+				RemainingTime:    240,
+				Grants: []kiku.LeaveGrant{
+					{GrantedAt: &grantedAt, ExpiresAt: &expiresAt, Days: 10, RemainingDays: 7.5},
+				},
+			},
+		},
+		"Failed": {
+			input:  `{"success":false,"errors":[{"code":"NOT_FOUND","message":"not found"}]}`,
+			expect: kiku.GetLeaveBalanceResponse{},
+			err:    &kiku.APIError{Errors: []kiku.Error{{Code: "NOT_FOUND", Message: "not found"}}},
+		},
+	}
+
+	for scenario, test := range tests {
+		var actual kiku.GetLeaveBalanceResponse
+		err := actual.Decode(strings.NewReader(test.input))
+		assert.Equal(t, test.err, err, scenario)
+		assert.Equal(t, test.expect, actual, scenario)
+	}
+}
@@ -0,0 +1,150 @@
+package kiku
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces the values of redactedKeys in logs.
+const redacted = "REDACTED"
+
+// redactedKeys are the query parameters and JSON fields which carry access tokens.
+var redactedKeys = []string{"token", "target"}
+
+// Logger is the interface for structured logging of the client.
+// args are alternating keys and values, so *slog.Logger satisfies Logger.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// StdLogger is the Logger writing to *log.Logger in the form "LEVEL msg key=value ...".
+type StdLogger struct {
+	logger *log.Logger
+	debug  bool
+}
+
+// NewStdLogger returns the Logger writing to l. Debug messages are written only when debug is true.
+func NewStdLogger(l *log.Logger, debug bool) *StdLogger {
+	return &StdLogger{logger: l, debug: debug}
+}
+
+// Debug writes msg with DEBUG level if it is enabled.
+func (s *StdLogger) Debug(msg string, args ...any) {
+	if s.debug {
+		s.print("DEBUG", msg, args)
+	}
+}
+
+// Info writes msg with INFO level.
+func (s *StdLogger) Info(msg string, args ...any) {
+	s.print("INFO", msg, args)
+}
+
+// Warn writes msg with WARN level.
+func (s *StdLogger) Warn(msg string, args ...any) {
+	s.print("WARN", msg, args)
+}
+
+// Error writes msg with ERROR level.
+func (s *StdLogger) Error(msg string, args ...any) {
+	s.print("ERROR", msg, args)
+}
+
+func (s *StdLogger) print(level, msg string, args []any) {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 < len(args) {
+			fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+		} else {
+			fmt.Fprintf(&b, " !BADKEY=%v", args[i])
+		}
+	}
+	s.logger.Print(b.String())
+}
+
+// nopLogger is the Logger discarding everything.
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+
+func isRedactedKey(key string) bool {
+	for _, k := range redactedKeys {
+		if strings.EqualFold(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL masks the values of redactedKeys in the query of rawURL.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return redacted
+	}
+	q := u.Query()
+	changed := false
+	for key := range q {
+		if isRedactedKey(key) {
+			q[key] = []string{redacted}
+			changed = true
+		}
+	}
+	if changed {
+		u.RawQuery = q.Encode()
+	}
+	return u.String()
+}
+
+// redactError masks the access token in the URL of *url.Error returned by http.Client.
+func redactError(err error) error {
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		ue.URL = redactURL(ue.URL)
+	}
+	return err
+}
+
+// redactJSON masks the values of redactedKeys at any depth of the JSON document b.
+func redactJSON(b []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return redacted
+	}
+	redactValue(v)
+	r, err := json.Marshal(v)
+	if err != nil {
+		return redacted
+	}
+	return string(r)
+}
+
+func redactValue(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isRedactedKey(key) {
+				v[key] = redacted
+				continue
+			}
+			redactValue(value)
+		}
+	case []interface{}:
+		for _, value := range v {
+			redactValue(value)
+		}
+	}
+}
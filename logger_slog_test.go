@@ -0,0 +1,11 @@
+//go:build go1.21
+
+package kiku_test
+
+import (
+	"log/slog"
+
+	"github.com/hapoon/kiku"
+)
+
+var _ kiku.Logger = slog.Default()
@@ -0,0 +1,124 @@
+package kiku_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+type logEntry struct {
+	level string
+	msg   string
+	args  map[string]interface{}
+}
+
+// recordingLogger is the Logger keeping every entry in memory.
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) record(level, msg string, args []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e := logEntry{level: level, msg: msg, args: map[string]interface{}{}}
+	for i := 0; i+1 < len(args); i += 2 {
+		e.args[fmt.Sprint(args[i])] = args[i+1]
+	}
+	l.entries = append(l.entries, e)
+}
+
+func (l *recordingLogger) Debug(msg string, args ...any) { l.record("DEBUG", msg, args) }
+func (l *recordingLogger) Info(msg string, args ...any)  { l.record("INFO", msg, args) }
+func (l *recordingLogger) Warn(msg string, args ...any)  { l.record("WARN", msg, args) }
+func (l *recordingLogger) Error(msg string, args ...any) { l.record("ERROR", msg, args) }
+
+func (l *recordingLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return fmt.Sprint(l.entries)
+}
+
+func Test_Client_Logger(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			io.WriteString(w, `{"success":true,"response":{"login_company_code":"foo","token":"new-secret"}}`)
+			return
+		}
+		io.WriteString(w, `{"success":true,"response":{"login_company_code":"foo"}}`)
+	}))
+	defer ts.Close()
+
+	logger := &recordingLogger{}
+	cli := kiku.NewClient(kiku.WithBaseURL(ts.URL), kiku.WithHTTPClient(ts.Client()), kiku.WithLogger(logger))
+
+	target, page := "target-secret", 1
+	_, err := cli.GetStaff(context.Background(), kiku.GetStaffParam{LoginCompanyCode: "foo", Token: "secret", Target: &target, Page: &page})
+	assert.NoError(t, err)
+	_, err = cli.PostTokenReissue(context.Background(), kiku.PostTokenReissueParam{LoginCompanyCode: "foo", Token: "secret"})
+	assert.NoError(t, err)
+
+	assert.NotContains(t, logger.String(), "secret")
+	assert.Len(t, logger.entries, 3)
+
+	get := logger.entries[0]
+	assert.Equal(t, "INFO", get.level)
+	assert.Equal(t, http.MethodGet, get.args["method"])
+	assert.Equal(t, "/foo/staffs?page=1&target=REDACTED&token=REDACTED", get.args["path"])
+	assert.Equal(t, http.StatusOK, get.args["status"])
+	assert.Equal(t, 1, get.args["attempt"])
+	assert.Contains(t, get.args, "duration")
+
+	body := logger.entries[1]
+	assert.Equal(t, "DEBUG", body.level)
+	assert.Equal(t, `{"LoginCompanyCode":"foo","token":"REDACTED"}`, body.args["body"])
+}
+
+func Test_Client_Logger_Error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+
+	logger := &recordingLogger{}
+	cli := kiku.NewClient(kiku.WithBaseURL(ts.URL), kiku.WithLogger(logger), kiku.WithRetryPolicy(nil))
+	_, err := cli.GetStaff(context.Background(), kiku.GetStaffParam{LoginCompanyCode: "foo", Token: "secret"})
+
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+	assert.NotContains(t, logger.String(), "secret")
+	assert.Len(t, logger.entries, 1)
+	assert.Equal(t, "ERROR", logger.entries[0].level)
+}
+
+func Test_StdLogger(t *testing.T) {
+	tests := map[string]struct {
+		debug  bool
+		expect string
+	}{
+		"Debug enabled": {
+			debug:  true,
+			expect: "DEBUG foo a=1\nINFO bar b=x\nWARN baz !BADKEY=c\nERROR qux\n",
+		},
+		"Debug disabled": {
+			expect: "INFO bar b=x\nWARN baz !BADKEY=c\nERROR qux\n",
+		},
+	}
+
+	for scenario, test := range tests {
+		var buf bytes.Buffer
+		l := kiku.NewStdLogger(log.New(&buf, "", 0), test.debug)
+		l.Debug("foo", "a", 1)
+		l.Info("bar", "b", "x")
+		l.Warn("baz", "c")
+		l.Error("qux")
+		assert.Equal(t, test.expect, buf.String(), scenario)
+	}
+}
@@ -0,0 +1,245 @@
+package kiku
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds in seconds of the histograms of Collector.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics is the hook receiving the events of HTTP requests to AKASHI.
+// endpoint is the path without the query, in which the company code and numeric IDs are
+// replaced with "{company}" and "{id}", such as "/{company}/stamps/{id}".
+type Metrics interface {
+	// ObserveRequest observes an attempt of a request. statusCode is 0 when err is not nil.
+	ObserveRequest(method, endpoint string, statusCode int, duration time.Duration, err error)
+	// ObserveRetry observes that a request is going to be retried.
+	ObserveRetry(method, endpoint string)
+	// ObserveRateLimitWait observes the time a request waited for RateLimiter.
+	ObserveRateLimitWait(companyCode string, wait time.Duration)
+}
+
+// WithMetrics sets the hook receiving the events of every HTTP request.
+func WithMetrics(metrics Metrics) Option {
+	return func(c *Client) {
+		c.metrics = metrics
+	}
+}
+
+// endpointOf returns the endpoint of path for Metrics.
+func endpointOf(path, companyCode string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		switch {
+		case s == "":
+		case s == companyCode:
+			segments[i] = "{company}"
+		case strings.Trim(s, "0123456789") == "":
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// statusClass returns the class of statusCode such as "2xx", or "error" if err is not nil.
+func statusClass(statusCode int, err error) string {
+	if err != nil || statusCode < 100 {
+		return "error"
+	}
+	return strconv.Itoa(statusCode/100) + "xx"
+}
+
+// Collector is the Metrics which exposes the metrics in the Prometheus text exposition format.
+// It is an http.Handler, so it can be mounted as "/metrics" on any HTTP server.
+// A Collector is safe for concurrent use by multiple goroutines.
+type Collector struct {
+	mu       sync.Mutex
+	buckets  []float64
+	requests map[[3]string]uint64
+	duration map[[2]string]*histogram
+	retries  map[[2]string]uint64
+	waits    map[string]*histogram
+}
+
+// NewCollector returns the Collector whose histograms have buckets, or DefaultBuckets if buckets is empty.
+func NewCollector(buckets ...float64) *Collector {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Collector{
+		buckets:  buckets,
+		requests: map[[3]string]uint64{},
+		duration: map[[2]string]*histogram{},
+		retries:  map[[2]string]uint64{},
+		waits:    map[string]*histogram{},
+	}
+}
+
+// ObserveRequest counts the request by its status class and observes its duration.
+func (c *Collector) ObserveRequest(method, endpoint string, statusCode int, duration time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests[[3]string{method, endpoint, statusClass(statusCode, err)}]++
+	key := [2]string{method, endpoint}
+	if c.duration[key] == nil {
+		c.duration[key] = newHistogram(c.buckets)
+	}
+	c.duration[key].observe(duration.Seconds())
+}
+
+// ObserveRetry counts the retry.
+func (c *Collector) ObserveRetry(method, endpoint string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.retries[[2]string{method, endpoint}]++
+}
+
+// ObserveRateLimitWait observes the wait.
+func (c *Collector) ObserveRateLimitWait(companyCode string, wait time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.waits[companyCode] == nil {
+		c.waits[companyCode] = newHistogram(c.buckets)
+	}
+	c.waits[companyCode].observe(wait.Seconds())
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format.
+// The metrics are rendered before writing, so a slow w does not block the observations.
+func (c *Collector) WriteTo(w io.Writer) (n int64, err error) {
+	var buf bytes.Buffer
+	c.render(&buf)
+	return buf.WriteTo(w)
+}
+
+// render renders the metrics to buf.
+func (c *Collector) render(buf *bytes.Buffer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fmt.Fprintln(buf, "# HELP kiku_requests_total Number of HTTP requests to AKASHI.")
+	fmt.Fprintln(buf, "# TYPE kiku_requests_total counter")
+	requests := make([][3]string, 0, len(c.requests))
+	for k := range c.requests {
+		requests = append(requests, k)
+	}
+	sort.Slice(requests, func(i, j int) bool { return lessLabels(requests[i][:], requests[j][:]) })
+	for _, k := range requests {
+		fmt.Fprintf(buf, "kiku_requests_total{%s} %d\n",
+			labels("method", k[0], "endpoint", k[1], "status_class", k[2]), c.requests[k])
+	}
+
+	fmt.Fprintln(buf, "# HELP kiku_request_duration_seconds Duration of HTTP requests to AKASHI.")
+	fmt.Fprintln(buf, "# TYPE kiku_request_duration_seconds histogram")
+	durations := make([][2]string, 0, len(c.duration))
+	for k := range c.duration {
+		durations = append(durations, k)
+	}
+	sort.Slice(durations, func(i, j int) bool { return lessLabels(durations[i][:], durations[j][:]) })
+	for _, k := range durations {
+		c.duration[k].write(buf, "kiku_request_duration_seconds", labels("method", k[0], "endpoint", k[1]))
+	}
+
+	fmt.Fprintln(buf, "# HELP kiku_retries_total Number of retried HTTP requests to AKASHI.")
+	fmt.Fprintln(buf, "# TYPE kiku_retries_total counter")
+	retries := make([][2]string, 0, len(c.retries))
+	for k := range c.retries {
+		retries = append(retries, k)
+	}
+	sort.Slice(retries, func(i, j int) bool { return lessLabels(retries[i][:], retries[j][:]) })
+	for _, k := range retries {
+		fmt.Fprintf(buf, "kiku_retries_total{%s} %d\n", labels("method", k[0], "endpoint", k[1]), c.retries[k])
+	}
+
+	fmt.Fprintln(buf, "# HELP kiku_rate_limit_wait_seconds Time spent waiting for the rate limiter.")
+	fmt.Fprintln(buf, "# TYPE kiku_rate_limit_wait_seconds histogram")
+	companies := make([]string, 0, len(c.waits))
+	for k := range c.waits {
+		companies = append(companies, k)
+	}
+	sort.Strings(companies)
+	for _, k := range companies {
+		c.waits[k].write(buf, "kiku_rate_limit_wait_seconds", labels("company", k))
+	}
+}
+
+// ServeHTTP writes the metrics as the response.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.WriteTo(w)
+}
+
+// histogram is the cumulative histogram of Prometheus.
+type histogram struct {
+	bounds []float64
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+}
+
+func (h *histogram) observe(v float64) {
+	for i, b := range h.bounds {
+		if v <= b {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+func (h *histogram) write(w io.Writer, name, labels string) {
+	for i, b := range h.bounds {
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, formatFloat(b), h.counts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+	fmt.Fprintf(w, "%s_sum{%s} %s\n", name, labels, formatFloat(h.sum))
+	fmt.Fprintf(w, "%s_count{%s} %d\n", name, labels, h.count)
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labels formats alternating names and values as the labels of a sample.
+func labels(pairs ...string) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=\"%s\"", pairs[i], labelEscaper.Replace(pairs[i+1]))
+	}
+	return b.String()
+}
+
+func lessLabels(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package kiku_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Client_WithMetrics(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		io.WriteString(w, `{"success":true,"response":{"login_company_code":"foo","staff_id":12}}`)
+	}))
+	defer ts.Close()
+
+	collector := kiku.NewCollector(0.5, 1)
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("secret"),
+		kiku.WithLogger(nil),
+		kiku.WithRetryPolicy(kiku.ExponentialBackoff{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		kiku.WithRateLimiter(kiku.NewRateLimiter(1000, 10)),
+		kiku.WithMetrics(collector),
+	)
+
+	start, end := time.Now(), time.Now()
+	_, err := cli.GetStamps(context.Background(), kiku.GetStampParam{StartDate: &start, EndDate: &end, StaffID: 12})
+	assert.NoError(t, err)
+
+	var b strings.Builder
+	n, err := collector.WriteTo(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(b.Len()), n)
+
+	actual := b.String()
+	for _, expect := range []string{
+		`kiku_requests_total{method="GET",endpoint="/{company}/stamps/{id}",status_class="2xx"} 1`,
+		`kiku_requests_total{method="GET",endpoint="/{company}/stamps/{id}",status_class="5xx"} 1`,
+		`kiku_request_duration_seconds_count{method="GET",endpoint="/{company}/stamps/{id}"} 2`,
+		`kiku_retries_total{method="GET",endpoint="/{company}/stamps/{id}"} 1`,
+		`kiku_rate_limit_wait_seconds_bucket{company="foo",le="+Inf"} 2`,
+		`kiku_rate_limit_wait_seconds_count{company="foo"} 2`,
+	} {
+		assert.Contains(t, actual, expect+"\n")
+	}
+	assert.NotContains(t, actual, "secret")
+}
+
+func Test_Collector_WriteTo(t *testing.T) {
+	c := kiku.NewCollector(1, 0.1)
+	c.ObserveRequest(http.MethodPost, "/{company}/stamps", http.StatusOK, 50*time.Millisecond, nil)
+	c.ObserveRequest(http.MethodPost, "/{company}/stamps", 0, 2*time.Second, errors.New("timeout"))
+	c.ObserveRequest(http.MethodGet, "/{company}/staffs", http.StatusNotFound, 500*time.Millisecond, nil)
+	c.ObserveRetry(http.MethodPost, "/{company}/stamps")
+	c.ObserveRateLimitWait(`a"b`, 0)
+
+	expect := `# HELP kiku_requests_total Number of HTTP requests to AKASHI.
+# TYPE kiku_requests_total counter
+kiku_requests_total{method="GET",endpoint="/{company}/staffs",status_class="4xx"} 1
+kiku_requests_total{method="POST",endpoint="/{company}/stamps",status_class="2xx"} 1
+kiku_requests_total{method="POST",endpoint="/{company}/stamps",status_class="error"} 1
+# HELP kiku_request_duration_seconds Duration of HTTP requests to AKASHI.
+# TYPE kiku_request_duration_seconds histogram
+kiku_request_duration_seconds_bucket{method="GET",endpoint="/{company}/staffs",le="0.1"} 0
+kiku_request_duration_seconds_bucket{method="GET",endpoint="/{company}/staffs",le="1"} 1
+kiku_request_duration_seconds_bucket{method="GET",endpoint="/{company}/staffs",le="+Inf"} 1
+kiku_request_duration_seconds_sum{method="GET",endpoint="/{company}/staffs"} 0.5
+kiku_request_duration_seconds_count{method="GET",endpoint="/{company}/staffs"} 1
+kiku_request_duration_seconds_bucket{method="POST",endpoint="/{company}/stamps",le="0.1"} 1
+kiku_request_duration_seconds_bucket{method="POST",endpoint="/{company}/stamps",le="1"} 1
+kiku_request_duration_seconds_bucket{method="POST",endpoint="/{company}/stamps",le="+Inf"} 2
+kiku_request_duration_seconds_sum{method="POST",endpoint="/{company}/stamps"} 2.05
+kiku_request_duration_seconds_count{method="POST",endpoint="/{company}/stamps"} 2
+# HELP kiku_retries_total Number of retried HTTP requests to AKASHI.
+# TYPE kiku_retries_total counter
+kiku_retries_total{method="POST",endpoint="/{company}/stamps"} 1
+# HELP kiku_rate_limit_wait_seconds Time spent waiting for the rate limiter.
+# TYPE kiku_rate_limit_wait_seconds histogram
+kiku_rate_limit_wait_seconds_bucket{company="a\"b",le="0.1"} 1
+kiku_rate_limit_wait_seconds_bucket{company="a\"b",le="1"} 1
+kiku_rate_limit_wait_seconds_bucket{company="a\"b",le="+Inf"} 1
+kiku_rate_limit_wait_seconds_sum{company="a\"b"} 0
+kiku_rate_limit_wait_seconds_count{company="a\"b"} 1
+`
+
+	var b strings.Builder
+	_, err := c.WriteTo(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, b.String())
+}
+
+// blockingWriter is the writer blocking until release is closed.
+type blockingWriter struct {
+	writing chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	close(w.writing)
+	<-w.release
+	return len(p), nil
+}
+
+func Test_Collector_WriteTo_Blocked(t *testing.T) {
+	c := kiku.NewCollector()
+	for i := 0; i < 50; i++ {
+		c.ObserveRequest(http.MethodGet, fmt.Sprintf("/{company}/endpoint%d", i), http.StatusOK, time.Millisecond, nil)
+	}
+	w := &blockingWriter{writing: make(chan struct{}), release: make(chan struct{})}
+	written := make(chan struct{})
+	go func() {
+		c.WriteTo(w)
+		close(written)
+	}()
+	<-w.writing
+
+	observed := make(chan struct{})
+	go func() {
+		c.ObserveRequest(http.MethodGet, "/{company}/staffs", http.StatusOK, time.Millisecond, nil)
+		c.ObserveRetry(http.MethodGet, "/{company}/staffs")
+		close(observed)
+	}()
+	select {
+	case <-observed:
+	case <-time.After(time.Second):
+		t.Error("observation is blocked by WriteTo")
+	}
+	close(w.release)
+	<-written
+}
+
+func Test_Collector_ServeHTTP(t *testing.T) {
+	c := kiku.NewCollector()
+	c.ObserveRetry(http.MethodGet, "/{company}/staffs")
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `kiku_retries_total{method="GET",endpoint="/{company}/staffs"} 1`)
+}
@@ -0,0 +1,186 @@
+package kiku
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// DefaultRequestIDHeader is the header in which RequestID sends the request ID.
+const DefaultRequestIDHeader = "X-Request-Id"
+
+// redactedHeaders are the headers masked by Dump.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Middleware is the function that wraps the transport of the client to intercept requests and responses.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is the adapter to use a function as http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware appends middlewares wrapping the transport of the HTTP client.
+// The first middleware is the outermost one, which sees each request first and each response last.
+// Middlewares see every attempt of a retried request. The HTTP client given by WithHTTPClient is not modified.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// chain returns a copy of httpClient whose transport is wrapped by middlewares.
+func chain(httpClient *http.Client, middlewares []Middleware) *http.Client {
+	if len(middlewares) == 0 {
+		return httpClient
+	}
+	hc := *httpClient
+	transport := hc.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+	hc.Transport = transport
+	return &hc
+}
+
+// UserAgent returns the Middleware setting the User-Agent header of every request.
+func UserAgent(userAgent string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("User-Agent", userAgent)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+type requestIDKey struct{}
+
+// requestIDSlotKey is the context key of the *string in which RequestID keeps the ID generated
+// for the first attempt of a call, so that its retries are sent with the same ID.
+type requestIDSlotKey struct{}
+
+// WithRequestID returns the context with which RequestID sends id instead of generating one.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFrom returns the request ID set by WithRequestID.
+func RequestIDFrom(ctx context.Context) (id string, ok bool) {
+	id, ok = ctx.Value(requestIDKey{}).(string)
+	return
+}
+
+// RequestID returns the Middleware sending a request ID in header, or DefaultRequestIDHeader if header is empty.
+// The ID is taken from the context by WithRequestID, or generated randomly for each call of Client
+// and shared by its retries.
+// A request which already has the header is sent as it is.
+func RequestID(header string) Middleware {
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(header) != "" {
+				return next.RoundTrip(req)
+			}
+			id, ok := RequestIDFrom(req.Context())
+			slot, _ := req.Context().Value(requestIDSlotKey{}).(*string)
+			if !ok && slot != nil && *slot != "" {
+				id, ok = *slot, true
+			}
+			if !ok {
+				b := make([]byte, 16)
+				if _, err := rand.Read(b); err != nil {
+					return nil, err
+				}
+				id = hex.EncodeToString(b)
+				if slot != nil {
+					*slot = id
+				}
+			}
+			req = req.Clone(req.Context())
+			req.Header.Set(header, id)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// Dump returns the Middleware writing every request and response to w for debugging.
+// Access tokens in URLs and bodies and credentials in headers are redacted.
+func Dump(w io.Writer) Middleware {
+	var mu sync.Mutex
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			var buf bytes.Buffer
+			fmt.Fprintf(&buf, "> %s %s\n", req.Method, redactURL(req.URL.String()))
+			dumpHeader(&buf, "> ", req.Header)
+			if req.Body != nil && req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				dumpBody(&buf, body)
+			}
+
+			res, err := next.RoundTrip(req)
+			if err != nil {
+				fmt.Fprintf(&buf, "< error: %v\n", redactError(err))
+			} else {
+				fmt.Fprintf(&buf, "< %s %s\n", res.Proto, res.Status)
+				dumpHeader(&buf, "< ", res.Header)
+				b, readErr := io.ReadAll(res.Body)
+				res.Body.Close()
+				if readErr != nil {
+					fmt.Fprintf(&buf, "< error: %v\n", redactError(readErr))
+					res, err = nil, readErr
+				} else {
+					res.Body = io.NopCloser(bytes.NewReader(b))
+					dumpBody(&buf, io.NopCloser(bytes.NewReader(b)))
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			w.Write(buf.Bytes())
+			return res, err
+		})
+	}
+}
+
+func dumpHeader(w io.Writer, prefix string, header http.Header) {
+	header = header.Clone()
+	for _, h := range redactedHeaders {
+		if header.Get(h) != "" {
+			header.Set(h, redacted)
+		}
+	}
+	var buf bytes.Buffer
+	header.Write(&buf)
+	for _, line := range bytes.SplitAfter(buf.Bytes(), []byte("\n")) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			fmt.Fprintf(w, "%s%s", prefix, bytes.TrimRight(line, "\r\n"))
+			io.WriteString(w, "\n")
+		}
+	}
+}
+
+func dumpBody(w io.Writer, body io.ReadCloser) {
+	defer body.Close()
+	b, err := io.ReadAll(body)
+	if err != nil || len(b) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s\n", redactJSON(b))
+}
@@ -0,0 +1,204 @@
+package kiku_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithMiddleware(t *testing.T) {
+	var headers http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		io.WriteString(w, `{"success":true,"response":{"login_company_code":"foo"}}`)
+	}))
+	defer ts.Close()
+
+	var order []string
+	trace := func(name string) kiku.Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return kiku.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	httpClient := ts.Client()
+	transport := httpClient.Transport
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithMiddleware(trace("first"), kiku.UserAgent("kiku-test")),
+		kiku.WithHTTPClient(httpClient),
+		kiku.WithMiddleware(trace("second"), kiku.RequestID("")),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+	)
+
+	_, err := cli.GetStaff(context.Background(), kiku.GetStaffParam{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, order)
+	assert.Equal(t, "kiku-test", headers.Get("User-Agent"))
+	assert.Len(t, headers.Get(kiku.DefaultRequestIDHeader), 32)
+	assert.Equal(t, transport, httpClient.Transport)
+}
+
+func Test_RequestID(t *testing.T) {
+	tests := map[string]struct {
+		ctx    context.Context
+		header string
+		preset string
+		expect string
+	}{
+		"Generated": {
+			ctx: context.Background(),
+		},
+		"From context": {
+			ctx:    kiku.WithRequestID(context.Background(), "foo"),
+			header: "X-Correlation-Id",
+			expect: "foo",
+		},
+		"Already set": {
+			ctx:    kiku.WithRequestID(context.Background(), "foo"),
+			preset: "bar",
+			expect: "bar",
+		},
+	}
+
+	for scenario, test := range tests {
+		var actual string
+		header := test.header
+		if header == "" {
+			header = kiku.DefaultRequestIDHeader
+		}
+		rt := kiku.RequestID(test.header)(kiku.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			actual = req.Header.Get(header)
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}))
+
+		req, _ := http.NewRequestWithContext(test.ctx, http.MethodGet, "http://example.com/", nil)
+		if test.preset != "" {
+			req.Header.Set(header, test.preset)
+		}
+		_, err := rt.RoundTrip(req)
+		assert.NoError(t, err, scenario)
+		if test.expect == "" {
+			assert.Len(t, actual, 32, scenario)
+		} else {
+			assert.Equal(t, test.expect, actual, scenario)
+		}
+	}
+}
+
+func Test_Dump(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"success":true,"response":{"login_company_code":"foo","token":"new-secret"}}`)
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLogger(nil),
+		kiku.WithMiddleware(
+			func(next http.RoundTripper) http.RoundTripper {
+				return kiku.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					req = req.Clone(req.Context())
+					req.Header.Set("Proxy-Authorization", "Basic secret")
+					return next.RoundTrip(req)
+				})
+			},
+			kiku.Dump(&buf),
+		),
+	)
+
+	res, err := cli.PostTokenReissue(context.Background(), kiku.PostTokenReissueParam{LoginCompanyCode: "foo", Token: "secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, "new-secret", res.Token)
+
+	dump := buf.String()
+	assert.NotContains(t, dump, "secret")
+	assert.True(t, strings.HasPrefix(dump, "> POST "+ts.URL+"/token/reissue/foo\n"), dump)
+	assert.Contains(t, dump, "> Proxy-Authorization: REDACTED\n")
+	assert.Contains(t, dump, `{"LoginCompanyCode":"foo","token":"REDACTED"}`)
+	assert.Contains(t, dump, "< HTTP/1.1 200 OK\n")
+	assert.Contains(t, dump, `"token":"REDACTED"`)
+}
+
+// failingBody is the response body whose Read always fails.
+type failingBody struct {
+	closed bool
+}
+
+func (b *failingBody) Read(p []byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
+
+func (b *failingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func Test_Dump_ReadError(t *testing.T) {
+	body := &failingBody{}
+	var buf bytes.Buffer
+	rt := kiku.Dump(&buf)(kiku.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{Proto: "HTTP/1.1", Status: "200 OK", StatusCode: http.StatusOK, Header: http.Header{}, Body: body}, nil
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/foo/staffs?token=secret", nil)
+	res, err := rt.RoundTrip(req)
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.True(t, body.closed)
+
+	dump := buf.String()
+	assert.NotContains(t, dump, "secret")
+	assert.True(t, strings.HasPrefix(dump, "> GET http://example.com/foo/staffs?token=REDACTED\n"), dump)
+	assert.Contains(t, dump, "< HTTP/1.1 200 OK\n")
+	assert.Contains(t, dump, "< error: unexpected EOF\n")
+}
+
+func Test_RequestID_Retry(t *testing.T) {
+	var ids []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(kiku.DefaultRequestIDHeader))
+		if len(ids)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, `{"success":true,"response":{"login_company_code":"foo"}}`)
+	}))
+	defer ts.Close()
+
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+		kiku.WithRetryPolicy(kiku.ExponentialBackoff{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		kiku.WithMiddleware(kiku.RequestID("")),
+	)
+	for i := 0; i < 2; i++ {
+		_, err := cli.GetStaff(context.Background(), kiku.GetStaffParam{})
+		assert.NoError(t, err)
+	}
+
+	// The retry of a call is sent with the same ID, and another call with a new one.
+	assert.Len(t, ids, 4)
+	assert.Len(t, ids[0], 32)
+	assert.Equal(t, ids[0], ids[1])
+	assert.Equal(t, ids[2], ids[3])
+	assert.NotEqual(t, ids[0], ids[2])
+}
@@ -1,7 +1,205 @@
 package kiku
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 // Organization is the struct has ID and name.
 type Organization struct {
-	ID   int    `json:"organizationId"` // 組織ID
-	Name string `json:"name"`           // 組織名
+	ID       int    `json:"organizationId"`     // 組織ID
+	Name     string `json:"name"`               // 組織名
+	Code     string `json:"code,omitempty"`     // 組織コード
+	ParentID int    `json:"parentId,omitempty"` // 親組織ID(最上位の場合は0)
+}
+
+// GetOrganizationsParam is the struct for the request parameters of GET Organization API.
+type GetOrganizationsParam struct {
+	LoginCompanyCode string // AKASHI企業ID
+	Token            string // アクセストークン
+	Page             *int   // ページ番号
+}
+
+// IsValid is the function to verify GetOrganizationsParam is correct.
+func (g GetOrganizationsParam) IsValid() (err error) {
+	switch {
+	case g.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case g.Token == "":
+		err = errors.New("Token must be set")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of GET Organization API.
+func (g GetOrganizationsParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/organizations", g.LoginCompanyCode)
+
+	uv := url.Values{}
+	uv.Add("token", g.Token)
+	if g.Page != nil {
+		uv.Add("page", strconv.Itoa(*g.Page))
+	}
+	encodedURL += "?" + uv.Encode()
+	return
+}
+
+// GetOrganizationsResponse is the struct representing the response of GET Organization API.
+type GetOrganizationsResponse struct {
+	LoginCompanyCode string         `json:"login_company_code"` // AKASHI企業ID
+	Count            int            `json:"Count"`              // 取得された組織数
+	TotalCount       int            `json:"TotalCount"`         // 取得することができる組織数
+	Organizations    []Organization `json:"organizations"`      // 組織の配列
+}
+
+// Decode is the function that decodes the response of GET Organization API.
+func (g *GetOrganizationsResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, g)
+}
+
+// GetOrganizations is the function that retrieves organizations from AKASHI.
+func GetOrganizations(ctx context.Context, param GetOrganizationsParam) (response GetOrganizationsResponse, err error) {
+	return defaultClient.GetOrganizations(ctx, param)
+}
+
+// GetOrganizations is the method that retrieves organizations from AKASHI.
+func (c *Client) GetOrganizations(ctx context.Context, param GetOrganizationsParam) (response GetOrganizationsResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "GetOrganizations", param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.get(ctx, param.LoginCompanyCode, param.EncodeURL())
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
+
+// ListAllOrganizations is the method that retrieves all organizations by walking every page.
+func (c *Client) ListAllOrganizations(ctx context.Context, param GetOrganizationsParam) (organizations []Organization, err error) {
+	for page := 1; ; page++ {
+		p := page
+		param.Page = &p
+
+		var res GetOrganizationsResponse
+		if res, err = c.GetOrganizations(ctx, param); err != nil {
+			return
+		}
+		organizations = append(organizations, res.Organizations...)
+		if len(res.Organizations) == 0 || len(organizations) >= res.TotalCount {
+			return
+		}
+	}
+}
+
+// OrganizationTree is the in-memory hierarchy of organizations.
+// It resolves IDs such as StampAttribute.OrgID and Staff.ManagedOrganizations into names and paths.
+type OrganizationTree struct {
+	organizations map[int]Organization
+	children      map[int][]int
+	roots         []int
+}
+
+// NewOrganizationTree builds the hierarchy of organizations.
+// An organization whose parent is not included is treated as a root.
+func NewOrganizationTree(organizations []Organization) *OrganizationTree {
+	t := &OrganizationTree{
+		organizations: make(map[int]Organization, len(organizations)),
+		children:      map[int][]int{},
+	}
+	for _, o := range organizations {
+		t.organizations[o.ID] = o
+	}
+	for _, o := range organizations {
+		if _, ok := t.organizations[o.ParentID]; ok && o.ParentID != o.ID {
+			t.children[o.ParentID] = append(t.children[o.ParentID], o.ID)
+		} else {
+			t.roots = append(t.roots, o.ID)
+		}
+	}
+
+	sort.Ints(t.roots)
+	for _, ids := range t.children {
+		sort.Ints(ids)
+	}
+	return t
+}
+
+func (t *OrganizationTree) list(ids []int) (organizations []Organization) {
+	for _, id := range ids {
+		organizations = append(organizations, t.organizations[id])
+	}
+	return
+}
+
+// Get returns the organization of id.
+func (t *OrganizationTree) Get(id int) (organization Organization, ok bool) {
+	organization, ok = t.organizations[id]
+	return
+}
+
+// Roots returns the top-level organizations ordered by ID.
+func (t *OrganizationTree) Roots() []Organization {
+	return t.list(t.roots)
+}
+
+// Children returns the direct children of id ordered by ID.
+func (t *OrganizationTree) Children(id int) []Organization {
+	return t.list(t.children[id])
+}
+
+// Parent returns the parent of id.
+func (t *OrganizationTree) Parent(id int) (parent Organization, ok bool) {
+	o, ok := t.organizations[id]
+	if !ok {
+		return
+	}
+	parent, ok = t.organizations[o.ParentID]
+	if o.ParentID == o.ID {
+		ok = false
+	}
+	return
+}
+
+// Path returns the organizations from the root down to id.
+// It returns nil if id is unknown.
+func (t *OrganizationTree) Path(id int) (path []Organization) {
+	visited := map[int]bool{}
+	for {
+		o, ok := t.organizations[id]
+		if !ok || visited[id] {
+			break
+		}
+		visited[id] = true
+		path = append([]Organization{o}, path...)
+		if o.ParentID == o.ID {
+			break
+		}
+		id = o.ParentID
+	}
+	return
+}
+
+// PathName returns the names on the path to id joined by sep, such as "本社/営業部/第一課".
+func (t *OrganizationTree) PathName(id int, sep string) string {
+	path := t.Path(id)
+	names := make([]string, len(path))
+	for i, o := range path {
+		names[i] = o.Name
+	}
+	return strings.Join(names, sep)
 }
@@ -0,0 +1,119 @@
+package kiku_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOrganizationsParam_IsValid(t *testing.T) {
+	tests := map[string]struct {
+		g      kiku.GetOrganizationsParam
+		expect error
+	}{
+		"Normal scenario": {
+			g: kiku.GetOrganizationsParam{LoginCompanyCode: "foo", Token: "bar"},
+		},
+		"Error scenario: LoginCompanyCode is empty": {
+			g:      kiku.GetOrganizationsParam{Token: "bar"},
+			expect: errors.New("LoginCompanyCode must be set"),
+		},
+		"Error scenario: Token is empty": {
+			g:      kiku.GetOrganizationsParam{LoginCompanyCode: "foo"},
+			expect: errors.New("Token must be set"),
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.expect, test.g.IsValid(), scenario)
+	}
+}
+
+func Test_GetOrganizationsParam_EncodeURL(t *testing.T) {
+	page := 2
+	tests := map[string]struct {
+		g      kiku.GetOrganizationsParam
+		expect string
+	}{
+		"Without page": {
+			g:      kiku.GetOrganizationsParam{LoginCompanyCode: "foo", Token: "bar"},
+			expect: "/foo/organizations?token=bar",
+		},
+		"With page": {
+			g:      kiku.GetOrganizationsParam{LoginCompanyCode: "foo", Token: "bar", Page: &page},
+			expect: "/foo/organizations?page=2&token=bar",
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.expect, test.g.EncodeURL(), scenario)
+	}
+}
+
+func Test_Client_ListAllOrganizations(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		switch page {
+		case 1:
+			fmt.Fprint(w, `{"success":true,"response":{"Count":2,"TotalCount":3,"organizations":[{"organizationId":1,"name":"本社"},{"organizationId":2,"name":"営業部","parentId":1}]}}`)
+		default:
+			fmt.Fprint(w, `{"success":true,"response":{"Count":1,"TotalCount":3,"organizations":[{"organizationId":3,"name":"第一課","parentId":2}]}}`)
+		}
+	}))
+	defer ts.Close()
+
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+	)
+	actual, err := cli.ListAllOrganizations(context.Background(), kiku.GetOrganizationsParam{})
+	assert.NoError(t, err)
+	assert.Equal(t, []kiku.Organization{
+		{ID: 1, Name: "本社"},
+		{ID: 2, Name: "営業部", ParentID: 1},
+		{ID: 3, Name: "第一課", ParentID: 2},
+	}, actual)
+}
+
+func Test_OrganizationTree(t *testing.T) {
+	tree := kiku.NewOrganizationTree([]kiku.Organization{
+		{ID: 3, Name: "第一課", ParentID: 2},
+		{ID: 1, Name: "本社"},
+		{ID: 2, Name: "営業部", ParentID: 1},
+		{ID: 4, Name: "開発部", ParentID: 1},
+		{ID: 5, Name: "子会社", ParentID: 99},
+	})
+
+	assert.Equal(t, []kiku.Organization{{ID: 1, Name: "本社"}, {ID: 5, Name: "子会社", ParentID: 99}}, tree.Roots())
+	assert.Equal(t, []kiku.Organization{{ID: 2, Name: "営業部", ParentID: 1}, {ID: 4, Name: "開発部", ParentID: 1}}, tree.Children(1))
+	assert.Empty(t, tree.Children(3))
+
+	parent, ok := tree.Parent(3)
+	assert.True(t, ok)
+	assert.Equal(t, "営業部", parent.Name)
+	_, ok = tree.Parent(1)
+	assert.False(t, ok)
+
+	tests := map[string]struct {
+		id     int
+		expect string
+	}{
+		"Root":       {id: 1, expect: "本社"},
+		"Descendant": {id: 3, expect: "本社/営業部/第一課"},
+		"Orphan":     {id: 5, expect: "子会社"},
+		"Unknown":    {id: 100, expect: ""},
+	}
+	for scenario, test := range tests {
+		assert.Equal(t, test.expect, tree.PathName(test.id, "/"), scenario)
+	}
+}
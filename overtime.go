@@ -0,0 +1,243 @@
+package kiku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// OvertimeApplication is the struct representing an overtime pre-approval application (残業申請).
+type OvertimeApplication struct {
+	ID             int               `json:"application_id"`   // 申請ID
+	StaffID        int               `json:"staff_id"`         // 申請者の従業員ID
+	Date           *AkTime           `json:"date"`             // 残業日
+	PlannedStartAt *AkTime           `json:"planned_start_at"` // 残業開始予定日時
+	PlannedEndAt   *AkTime           `json:"planned_end_at"`   // 残業終了予定日時
+	Reason         string            `json:"reason"`           // 申請理由
+	Status         ApplicationStatus `json:"status"`           // 申請状態
+	AppliedAt      *AkTime           `json:"applied_at"`       // 申請日時
+	ApprovalRoute  []ApprovalStep    `json:"approval_route"`   // 承認経路
+}
+
+// PlannedDuration returns the planned length of the overtime.
+func (o OvertimeApplication) PlannedDuration() time.Duration {
+	if o.PlannedStartAt == nil || o.PlannedEndAt == nil {
+		return 0
+	}
+	return o.PlannedEndAt.Sub(o.PlannedStartAt.Time)
+}
+
+// CurrentStep returns the first step of the approval route still waiting for approval.
+func (o OvertimeApplication) CurrentStep() (step ApprovalStep, ok bool) {
+	for _, s := range o.ApprovalRoute {
+		if s.Status == ApplicationStatusPending {
+			return s, true
+		}
+	}
+	return
+}
+
+// PostOvertimeApplicationParam is the struct for the request parameters of POST Overtime Application API.
+type PostOvertimeApplicationParam struct {
+	LoginCompanyCode string  `json:"-"`                // AKASHI企業ID
+	Token            string  `json:"token"`            // アクセストークン
+	Date             *AkTime `json:"date"`             // 残業日
+	PlannedStartAt   *AkTime `json:"planned_start_at"` // 残業開始予定日時
+	PlannedEndAt     *AkTime `json:"planned_end_at"`   // 残業終了予定日時
+	Reason           string  `json:"reason"`           // 申請理由
+}
+
+// IsValid is the function to verify PostOvertimeApplicationParam is correct.
+func (p PostOvertimeApplicationParam) IsValid() (err error) {
+	switch {
+	case p.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case p.Token == "":
+		err = errors.New("Token must be set")
+	case p.Date == nil:
+		err = errors.New("Date must be set")
+	case p.PlannedStartAt == nil:
+		err = errors.New("PlannedStartAt must be set")
+	case p.PlannedEndAt == nil:
+		err = errors.New("PlannedEndAt must be set")
+	case !p.PlannedEndAt.After(p.PlannedStartAt.Time):
+		err = errors.New("PlannedEndAt must be after PlannedStartAt")
+	case p.Reason == "":
+		err = errors.New("Reason must be set")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of POST Overtime Application API.
+func (p PostOvertimeApplicationParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/applications/overtimes", p.LoginCompanyCode)
+	return
+}
+
+// OvertimeApplicationResponse is the struct representing the response of submitting, approving or rejecting an overtime application.
+type OvertimeApplicationResponse struct {
+	LoginCompanyCode string              `json:"login_company_code"` // AKASHI企業ID
+	Application      OvertimeApplication `json:"application"`        // 残業申請
+}
+
+// Decode is the function that decodes OvertimeApplicationResponse.
+func (p *OvertimeApplicationResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, p)
+}
+
+// PostOvertimeApplication is the function that submits an overtime application to AKASHI.
+func PostOvertimeApplication(ctx context.Context, param PostOvertimeApplicationParam) (response OvertimeApplicationResponse, err error) {
+	return defaultClient.PostOvertimeApplication(ctx, param)
+}
+
+// PostOvertimeApplication is the method that submits an overtime application to AKASHI.
+func (c *Client) PostOvertimeApplication(ctx context.Context, param PostOvertimeApplicationParam) (response OvertimeApplicationResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "PostOvertimeApplication", param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.post(ctx, param.LoginCompanyCode, param.EncodeURL(), param)
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
+
+// GetOvertimeApplicationsParam is the struct for the request parameters of GET Overtime Application API.
+type GetOvertimeApplicationsParam struct {
+	LoginCompanyCode string             // AKASHI企業ID
+	Token            string             // アクセストークン
+	StaffID          *int               // 絞り込む申請者の従業員ID
+	StartDate        *time.Time         // 絞り込む残業日の開始日
+	EndDate          *time.Time         // 絞り込む残業日の終了日
+	Status           *ApplicationStatus // 絞り込む申請状態
+}
+
+// IsValid is the function to verify GetOvertimeApplicationsParam is correct.
+func (g GetOvertimeApplicationsParam) IsValid() (err error) {
+	switch {
+	case g.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case g.Token == "":
+		err = errors.New("Token must be set")
+	case g.StartDate != nil && g.EndDate != nil && g.EndDate.Before(*g.StartDate):
+		err = errors.New("EndDate must not be before StartDate")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of GET Overtime Application API.
+func (g GetOvertimeApplicationsParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/applications/overtimes", g.LoginCompanyCode)
+
+	uv := url.Values{}
+	uv.Add("token", g.Token)
+	if g.StaffID != nil {
+		uv.Add("staff_id", strconv.Itoa(*g.StaffID))
+	}
+	if g.StartDate != nil {
+		uv.Add("start_date", g.StartDate.In(Location).Format(DateFormat))
+	}
+	if g.EndDate != nil {
+		uv.Add("end_date", g.EndDate.In(Location).Format(DateFormat))
+	}
+	if g.Status != nil {
+		uv.Add("status", strconv.Itoa(int(*g.Status)))
+	}
+	encodedURL += "?" + uv.Encode()
+	return
+}
+
+// GetOvertimeApplicationsResponse is the struct representing the response of GET Overtime Application API.
+type GetOvertimeApplicationsResponse struct {
+	LoginCompanyCode string                `json:"login_company_code"` // AKASHI企業ID
+	Count            int                   `json:"count"`              // 残業申請数
+	Applications     []OvertimeApplication `json:"applications"`       // 残業申請の配列
+}
+
+// Decode is the function that decodes the response of GET Overtime Application API.
+func (g *GetOvertimeApplicationsResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, g)
+}
+
+// GetOvertimeApplications is the function that retrieves overtime applications from AKASHI.
+func GetOvertimeApplications(ctx context.Context, param GetOvertimeApplicationsParam) (response GetOvertimeApplicationsResponse, err error) {
+	return defaultClient.GetOvertimeApplications(ctx, param)
+}
+
+// GetOvertimeApplications is the method that retrieves overtime applications from AKASHI.
+func (c *Client) GetOvertimeApplications(ctx context.Context, param GetOvertimeApplicationsParam) (response GetOvertimeApplicationsResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "GetOvertimeApplications", param.LoginCompanyCode, intValue(param.StaffID))
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.get(ctx, param.LoginCompanyCode, param.EncodeURL())
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
+
+// ApproveOvertimeApplication is the function that approves an overtime application as a manager.
+func ApproveOvertimeApplication(ctx context.Context, param ApplicationDecisionParam) (response OvertimeApplicationResponse, err error) {
+	return defaultClient.ApproveOvertimeApplication(ctx, param)
+}
+
+// ApproveOvertimeApplication is the method that approves an overtime application as a manager.
+func (c *Client) ApproveOvertimeApplication(ctx context.Context, param ApplicationDecisionParam) (response OvertimeApplicationResponse, err error) {
+	return c.decideOvertimeApplication(ctx, param, decisionApprove)
+}
+
+// RejectOvertimeApplication is the function that rejects an overtime application as a manager.
+func RejectOvertimeApplication(ctx context.Context, param ApplicationDecisionParam) (response OvertimeApplicationResponse, err error) {
+	return defaultClient.RejectOvertimeApplication(ctx, param)
+}
+
+// RejectOvertimeApplication is the method that rejects an overtime application as a manager.
+func (c *Client) RejectOvertimeApplication(ctx context.Context, param ApplicationDecisionParam) (response OvertimeApplicationResponse, err error) {
+	return c.decideOvertimeApplication(ctx, param, decisionReject)
+}
+
+func (c *Client) decideOvertimeApplication(ctx context.Context, param ApplicationDecisionParam, decision string) (response OvertimeApplicationResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	op := "ApproveOvertimeApplication"
+	if decision == decisionReject {
+		op = "RejectOvertimeApplication"
+	}
+	ctx, call := c.startCall(ctx, op, param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.post(ctx, param.LoginCompanyCode, param.encodeURL("overtimes", decision), param)
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
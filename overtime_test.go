@@ -0,0 +1,79 @@
+package kiku_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PostOvertimeApplicationParam_IsValid(t *testing.T) {
+	date := kiku.AkTime{Time: time.Date(2000, time.January, 2, 0, 0, 0, 0, time.UTC)}
+	start := kiku.AkTime{Time: time.Date(2000, time.January, 2, 18, 0, 0, 0, time.UTC)}
+	end := kiku.AkTime{Time: time.Date(2000, time.January, 2, 20, 0, 0, 0, time.UTC)}
+	tests := map[string]struct {
+		p   kiku.PostOvertimeApplicationParam
+		err error
+	}{
+		"Necessary parameter set": {
+			p: kiku.PostOvertimeApplicationParam{LoginCompanyCode: "foo", Token: "bar", Date: &date, PlannedStartAt: &start, PlannedEndAt: &end, Reason: "リリース対応"},
+		},
+		"Date is not set": {
+			p:   kiku.PostOvertimeApplicationParam{LoginCompanyCode: "foo", Token: "bar", PlannedStartAt: &start, PlannedEndAt: &end, Reason: "リリース対応"},
+			err: errors.New("Date must be set"),
+		},
+		"PlannedEndAt is before PlannedStartAt": {
+			p:   kiku.PostOvertimeApplicationParam{LoginCompanyCode: "foo", Token: "bar", Date: &date, PlannedStartAt: &end, PlannedEndAt: &start, Reason: "リリース対応"},
+			err: errors.New("PlannedEndAt must be after PlannedStartAt"),
+		},
+		"Reason is not set": {
+			p:   kiku.PostOvertimeApplicationParam{LoginCompanyCode: "foo", Token: "bar", Date: &date, PlannedStartAt: &start, PlannedEndAt: &end},
+			err: errors.New("Reason must be set"),
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.err, test.p.IsValid(), scenario)
+	}
+}
+
+func Test_GetOvertimeApplicationsResponse_Decode(t *testing.T) {
+	var actual kiku.GetOvertimeApplicationsResponse
+	err := actual.Decode(strings.NewReader(`{
+		"success":true,
+		"response":{
+			"login_company_code":"foo",
+			"count":1,
+			"applications":[{
+				"application_id":1,
+				"staff_id":10,
+				"date":"2000/01/02 00:00:00",
+				"planned_start_at":"2000/01/02 18:00:00",
+				"planned_end_at":"2000/01/02 20:30:00",
+				"reason":"リリース対応",
+				"status":1,
+				"approval_route":[
+					{"order":1,"approver_staff_ids":[20],"status":2,"decided_by":20,"decided_at":"2000/01/01 12:00:00"},
+					{"order":2,"approver_staff_ids":[30,40],"status":1}
+				]
+			}]
+		}
+	}`))
+	assert.NoError(t, err)
+	assert.Len(t, actual.Applications, 1)
+
+	application := actual.Applications[0]
+	assert.Equal(t, 150*time.Minute, application.PlannedDuration())
+	assert.Len(t, application.ApprovalRoute, 2)
+	assert.Equal(t, 20, application.ApprovalRoute[0].DecidedBy)
+
+	step, ok := application.CurrentStep()
+	assert.True(t, ok)
+	assert.Equal(t, 2, step.Order)
+
+	staffs := []kiku.Staff{{ID: 10}, {ID: 20}, {ID: 30}, {ID: 40}}
+	assert.Equal(t, []kiku.Staff{{ID: 30}, {ID: 40}}, step.Approvers(staffs))
+}
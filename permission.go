@@ -0,0 +1,99 @@
+package kiku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// PermissionType is the integer represents the type of permission group.
+type PermissionType int
+
+const (
+	// PermissionTypeUnknown 権限種別:不明
+	PermissionTypeUnknown PermissionType = 0
+	// PermissionTypeCompanyAdministrator 権限種別:企業管理者
+	PermissionTypeCompanyAdministrator PermissionType = 1
+	// PermissionTypeManager 権限種別:一般管理者
+	PermissionTypeManager PermissionType = 2
+	// PermissionTypeEmployee 権限種別:従業員
+	PermissionTypeEmployee PermissionType = 3
+)
+
+func (p PermissionType) String() string {
+	switch p {
+	case PermissionTypeCompanyAdministrator:
+		return "企業管理者"
+	case PermissionTypeManager:
+		return "一般管理者"
+	case PermissionTypeEmployee:
+		return "従業員"
+	default:
+		return ""
+	}
+}
+
+// GetPermissionGroupsParam is the struct for the request parameters of GET Permission Group API.
+type GetPermissionGroupsParam struct {
+	LoginCompanyCode string // AKASHI企業ID
+	Token            string // アクセストークン
+}
+
+// IsValid is the function to verify GetPermissionGroupsParam is correct.
+func (g GetPermissionGroupsParam) IsValid() (err error) {
+	switch {
+	case g.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case g.Token == "":
+		err = errors.New("Token must be set")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of GET Permission Group API.
+func (g GetPermissionGroupsParam) EncodeURL() (encodedURL string) {
+	uv := url.Values{}
+	uv.Add("token", g.Token)
+	encodedURL = fmt.Sprintf("/%s/permission_groups?%s", g.LoginCompanyCode, uv.Encode())
+	return
+}
+
+// GetPermissionGroupsResponse is the struct representing the response of GET Permission Group API.
+type GetPermissionGroupsResponse struct {
+	LoginCompanyCode string            `json:"login_company_code"` // AKASHI企業ID
+	Count            int               `json:"count"`              // 権限グループ数
+	PermissionGroups []PermissionGroup `json:"permission_groups"`  // 権限グループの配列
+}
+
+// Decode is the function that decodes the response of GET Permission Group API.
+func (g *GetPermissionGroupsResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, g)
+}
+
+// GetPermissionGroups is the function that retrieves the permission groups from AKASHI.
+func GetPermissionGroups(ctx context.Context, param GetPermissionGroupsParam) (response GetPermissionGroupsResponse, err error) {
+	return defaultClient.GetPermissionGroups(ctx, param)
+}
+
+// GetPermissionGroups is the method that retrieves the permission groups from AKASHI.
+func (c *Client) GetPermissionGroups(ctx context.Context, param GetPermissionGroupsParam) (response GetPermissionGroupsResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "GetPermissionGroups", param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.get(ctx, param.LoginCompanyCode, param.EncodeURL())
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
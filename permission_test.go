@@ -0,0 +1,60 @@
+package kiku_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PermissionType_String(t *testing.T) {
+	tests := map[kiku.PermissionType]string{
+		kiku.PermissionTypeUnknown:              "",
+		kiku.PermissionTypeCompanyAdministrator: "企業管理者",
+		kiku.PermissionTypeManager:              "一般管理者",
+		kiku.PermissionTypeEmployee:             "従業員",
+	}
+
+	for p, expect := range tests {
+		assert.Equal(t, expect, p.String())
+	}
+}
+
+func Test_Client_MasterAPIs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/foo/permission_groups":
+			io.WriteString(w, `{"success":true,"response":{"count":2,"permission_groups":[`+
+				`{"permissionGroupId":1,"permissionType":1,"name":"管理者"},`+
+				`{"permissionGroupId":2,"permissionType":3,"name":"一般"}]}}`)
+		case "/foo/employment_categories":
+			io.WriteString(w, `{"success":true,"response":{"count":1,"employment_categories":[{"employmentCategoryId":1,"Name":"正社員"}]}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+	)
+
+	groups, err := cli.GetPermissionGroups(context.Background(), kiku.GetPermissionGroupsParam{})
+	assert.NoError(t, err)
+	assert.Equal(t, []kiku.PermissionGroup{
+		{ID: 1, Type: kiku.PermissionTypeCompanyAdministrator, Name: "管理者"},
+		{ID: 2, Type: kiku.PermissionTypeEmployee, Name: "一般"},
+	}, groups.PermissionGroups)
+
+	categories, err := cli.GetEmploymentCategories(context.Background(), kiku.GetEmploymentCategoriesParam{})
+	assert.NoError(t, err)
+	assert.Equal(t, []kiku.EmploymentCategory{{ID: 1, Name: "正社員"}}, categories.EmploymentCategories)
+}
@@ -0,0 +1,120 @@
+package kiku
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateRecovery is the period in which a throttled bucket recovers its full rate.
+const rateRecovery = 30 * time.Second
+
+// RateLimiter is the token-bucket rate limiter keyed by AKASHI企業ID.
+// Sharing one RateLimiter among clients shares the quota of each company.
+// A RateLimiter is safe for concurrent use by multiple goroutines.
+type RateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens       float64
+	rate         float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// NewRateLimiter returns the RateLimiter allowing rps requests per second with burst for each company.
+// It panics if rps is not positive. A burst less than 1 is treated as 1.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	if !(rps > 0) {
+		panic("kiku: non-positive rps for NewRateLimiter")
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:    rps,
+		burst:   float64(burst),
+		buckets: map[string]*bucket{},
+	}
+}
+
+// bucket returns the refilled bucket of key. l.mu must be held.
+func (l *RateLimiter) bucket(key string, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, rate: l.rate, last: now}
+		l.buckets[key] = b
+		return b
+	}
+
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+		b.rate += l.rate * elapsed / rateRecovery.Seconds()
+		if b.rate > l.rate {
+			b.rate = l.rate
+		}
+		b.last = now
+	}
+	return b
+}
+
+// Wait blocks until a request for key is allowed or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context, key string) (err error) {
+	l.mu.Lock()
+	now := time.Now()
+	b := l.bucket(key, now)
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	if d := b.blockedUntil.Sub(now); d > wait {
+		wait = d
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		b.tokens++
+		l.mu.Unlock()
+		err = ctx.Err()
+	case <-timer.C:
+	}
+	return
+}
+
+// Throttle slows down the requests for key after AKASHI rejected one with 429.
+// The rate is halved and recovers gradually, and no request is allowed until retryAfter elapses.
+func (l *RateLimiter) Throttle(key string, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	b := l.bucket(key, now)
+	b.rate /= 2
+	if floor := l.rate / 16; b.rate < floor {
+		b.rate = floor
+	}
+	if b.tokens > 0 {
+		b.tokens = 0
+	}
+	if until := now.Add(retryAfter); until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
@@ -0,0 +1,142 @@
+package kiku_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RateLimiter_Wait(t *testing.T) {
+	tests := map[string]struct {
+		rps     float64
+		burst   int
+		keys    []string
+		minWait time.Duration
+		maxWait time.Duration
+	}{
+		"Burst is not blocked": {
+			rps:     1,
+			burst:   3,
+			keys:    []string{"foo", "foo", "foo"},
+			maxWait: 50 * time.Millisecond,
+		},
+		"Requests beyond burst are paced": {
+			rps:     20,
+			burst:   1,
+			keys:    []string{"foo", "foo", "foo"},
+			minWait: 90 * time.Millisecond,
+		},
+		"Buckets are separated by company": {
+			rps:     1,
+			burst:   1,
+			keys:    []string{"foo", "bar", "baz"},
+			maxWait: 50 * time.Millisecond,
+		},
+	}
+
+	for scenario, test := range tests {
+		l := kiku.NewRateLimiter(test.rps, test.burst)
+		start := time.Now()
+		for _, key := range test.keys {
+			assert.NoError(t, l.Wait(context.Background(), key), scenario)
+		}
+		elapsed := time.Since(start)
+		assert.GreaterOrEqual(t, elapsed, test.minWait, scenario)
+		if test.maxWait > 0 {
+			assert.Less(t, elapsed, test.maxWait, scenario)
+		}
+	}
+}
+
+func Test_NewRateLimiter_NonPositiveRPS(t *testing.T) {
+	tests := map[string]float64{
+		"Zero":     0,
+		"Negative": -1,
+	}
+	for scenario, rps := range tests {
+		assert.Panics(t, func() { kiku.NewRateLimiter(rps, 1) }, scenario)
+	}
+}
+
+func Test_RateLimiter_Wait_ContextCanceled(t *testing.T) {
+	l := kiku.NewRateLimiter(0.1, 1)
+	assert.NoError(t, l.Wait(context.Background(), "foo"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Wait(ctx, "foo"), context.DeadlineExceeded)
+}
+
+func Test_RateLimiter_Throttle(t *testing.T) {
+	l := kiku.NewRateLimiter(1000, 10)
+	l.Throttle("foo", 50*time.Millisecond)
+
+	start := time.Now()
+	assert.NoError(t, l.Wait(context.Background(), "bar"))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+	assert.NoError(t, l.Wait(context.Background(), "foo"))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func Test_Client_RateLimiter(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		io.WriteString(w, `{"success":true,"response":{}}`)
+	}))
+	defer ts.Close()
+
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+		kiku.WithRateLimiter(kiku.NewRateLimiter(20, 1)),
+		kiku.WithRetryPolicy(kiku.ExponentialBackoff{MaxAttempts: 2}),
+	)
+
+	start := time.Now()
+	_, err := cli.GetStaff(context.Background(), kiku.GetStaffParam{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	// The rate is halved to 10 rps after 429, so the retry waits about 100ms.
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func Test_Client_RateLimiter_ContextCanceled(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+		kiku.WithRateLimiter(kiku.NewRateLimiter(1, 1)),
+		kiku.WithRetryPolicy(kiku.ExponentialBackoff{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// The retry waits for the rate limiter beyond the deadline, so the error is not the previous 503.
+	_, err := cli.GetStaff(ctx, kiku.GetStaffParam{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
@@ -0,0 +1,64 @@
+package kiku
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// decodeError converts the JSON decoding error into a readable one.
+func decodeError(err error) error {
+	var e *json.UnmarshalTypeError
+	if errors.As(err, &e) {
+		return fmt.Errorf("Unmarshal error: field: %s, value: %s", e.Field, e.Value)
+	}
+	return err
+}
+
+// decodeResponse decodes the body of res with decode.
+// A non-200 response is converted into *APIError, and *APIError is completed with the request information.
+func decodeResponse(res *http.Response, decode func(io.Reader) error) (err error) {
+	if res.StatusCode == http.StatusOK {
+		err = decode(res.Body)
+	} else {
+		var decoded struct {
+			Errors []Error `json:"errors"`
+		}
+		// The body of a failed request is not always JSON, so the error is ignored.
+		_ = json.NewDecoder(res.Body).Decode(&decoded)
+		err = &APIError{Errors: decoded.Errors}
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		apiErr.StatusCode = res.StatusCode
+		if res.Request != nil {
+			apiErr.Method = res.Request.Method
+			apiErr.Path = res.Request.URL.Path
+		}
+	}
+	return
+}
+
+// decodeEnvelope decodes the body of AKASHI API into response.
+// response is left untouched unless the request succeeded.
+func decodeEnvelope[T any](r io.Reader, response *T) (err error) {
+	var decoded struct {
+		Success  bool    `json:"success"`
+		Response T       `json:"response"`
+		Errors   []Error `json:"errors"`
+	}
+
+	err = json.NewDecoder(r).Decode(&decoded)
+	switch {
+	case err != nil:
+		err = decodeError(err)
+	case !decoded.Success:
+		err = &APIError{Errors: decoded.Errors}
+	default:
+		*response = decoded.Response
+	}
+	return
+}
@@ -0,0 +1,98 @@
+package kiku
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy is the interface that decides whether a request is retried.
+type RetryPolicy interface {
+	// Retry is called after the attempt-th attempt finished with res or err.
+	// It returns the wait before the next attempt, and false if the request must not be retried.
+	Retry(attempt int, res *http.Response, err error) (wait time.Duration, retry bool)
+}
+
+// ExponentialBackoff is the RetryPolicy that retries 5xx, 429 and network errors with exponential backoff.
+// Retry-After header is honored when the server sends it, but the request is not retried
+// if Retry-After exceeds MaxDelay.
+type ExponentialBackoff struct {
+	MaxAttempts int           // 最大試行回数(初回を含む)
+	BaseDelay   time.Duration // 初回リトライまでの待機時間
+	MaxDelay    time.Duration // 待機時間の上限
+	Jitter      float64       // 待機時間をランダムに短縮する割合(0〜1)
+}
+
+// DefaultRetryPolicy is the RetryPolicy used by NewClient.
+var DefaultRetryPolicy RetryPolicy = ExponentialBackoff{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+	Jitter:      0.5,
+}
+
+// Retry implements RetryPolicy.
+func (e ExponentialBackoff) Retry(attempt int, res *http.Response, err error) (wait time.Duration, retry bool) {
+	if attempt >= e.MaxAttempts {
+		return
+	}
+
+	switch {
+	case err != nil:
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return
+		}
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError:
+		if d, ok := retryAfter(res); ok {
+			if e.MaxDelay > 0 && d > e.MaxDelay {
+				return
+			}
+			return d, true
+		}
+	default:
+		return
+	}
+
+	wait = e.BaseDelay << (attempt - 1)
+	if e.MaxDelay > 0 && (wait > e.MaxDelay || wait <= 0) {
+		wait = e.MaxDelay
+	}
+	if e.Jitter > 0 {
+		wait -= time.Duration(rand.Float64() * e.Jitter * float64(wait))
+	}
+	return wait, true
+}
+
+// retryAfter parses Retry-After header of res.
+func retryAfter(res *http.Response) (d time.Duration, ok bool) {
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return
+	}
+	if sec, err := strconv.Atoi(v); err == nil && sec >= 0 {
+		return time.Duration(sec) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d = time.Until(t); d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns the context that marks a non-idempotent request such as PostStamp as safe to retry.
+// The key is sent as Idempotency-Key header on every attempt.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+func idempotencyKeyFrom(ctx context.Context) (key string, ok bool) {
+	key, ok = ctx.Value(idempotencyKey{}).(string)
+	return
+}
@@ -0,0 +1,184 @@
+package kiku_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExponentialBackoff_Retry(t *testing.T) {
+	policy := kiku.ExponentialBackoff{
+		MaxAttempts: 3,
+		BaseDelay:   time.Second,
+		MaxDelay:    3 * time.Second,
+	}
+	header := func(kv ...string) http.Header {
+		h := http.Header{}
+		for i := 0; i < len(kv); i += 2 {
+			h.Set(kv[i], kv[i+1])
+		}
+		return h
+	}
+	tests := map[string]struct {
+		attempt int
+		res     *http.Response
+		err     error
+		wait    time.Duration
+		retry   bool
+	}{
+		"5xx is retried": {
+			attempt: 1,
+			res:     &http.Response{StatusCode: http.StatusServiceUnavailable, Header: header()},
+			wait:    time.Second,
+			retry:   true,
+		},
+		"Backoff grows exponentially": {
+			attempt: 2,
+			res:     &http.Response{StatusCode: http.StatusInternalServerError, Header: header()},
+			wait:    2 * time.Second,
+			retry:   true,
+		},
+		"Retry-After is honored": {
+			attempt: 1,
+			res:     &http.Response{StatusCode: http.StatusTooManyRequests, Header: header("Retry-After", "2")},
+			wait:    2 * time.Second,
+			retry:   true,
+		},
+		"Retry-After exceeding MaxDelay is not retried": {
+			attempt: 1,
+			res:     &http.Response{StatusCode: http.StatusTooManyRequests, Header: header("Retry-After", "3600")},
+		},
+		"Network error is retried": {
+			attempt: 1,
+			err:     io.ErrUnexpectedEOF,
+			wait:    time.Second,
+			retry:   true,
+		},
+		"Canceled context is not retried": {
+			attempt: 1,
+			err:     context.Canceled,
+		},
+		"4xx is not retried": {
+			attempt: 1,
+			res:     &http.Response{StatusCode: http.StatusBadRequest, Header: header()},
+		},
+		"MaxAttempts is reached": {
+			attempt: 3,
+			res:     &http.Response{StatusCode: http.StatusServiceUnavailable, Header: header()},
+		},
+	}
+
+	for scenario, test := range tests {
+		wait, retry := policy.Retry(test.attempt, test.res, test.err)
+		assert.Equal(t, test.wait, wait, scenario)
+		assert.Equal(t, test.retry, retry, scenario)
+	}
+}
+
+func Test_Client_Retry(t *testing.T) {
+	policy := kiku.ExponentialBackoff{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+	}
+	tests := map[string]struct {
+		failures int32
+		call     func(ctx context.Context, cli *kiku.Client) error
+		attempts int32
+		success  bool
+	}{
+		"GET is retried until success": {
+			failures: 2,
+			call: func(ctx context.Context, cli *kiku.Client) error {
+				_, err := cli.GetStaff(ctx, kiku.GetStaffParam{})
+				return err
+			},
+			attempts: 3,
+			success:  true,
+		},
+		"GET gives up at MaxAttempts": {
+			failures: 5,
+			call: func(ctx context.Context, cli *kiku.Client) error {
+				_, err := cli.GetStaff(ctx, kiku.GetStaffParam{})
+				return err
+			},
+			attempts: 3,
+		},
+		"POST stamp is not retried without idempotency key": {
+			failures: 1,
+			call: func(ctx context.Context, cli *kiku.Client) error {
+				_, err := cli.PostStamp(ctx, kiku.PostStampParam{})
+				return err
+			},
+			attempts: 1,
+		},
+		"POST stamp is retried with idempotency key": {
+			failures: 1,
+			call: func(ctx context.Context, cli *kiku.Client) error {
+				_, err := cli.PostStamp(kiku.WithIdempotencyKey(ctx, "key"), kiku.PostStampParam{})
+				return err
+			},
+			attempts: 2,
+			success:  true,
+		},
+		"Token reissue is never retried": {
+			failures: 1,
+			call: func(ctx context.Context, cli *kiku.Client) error {
+				_, err := cli.PostTokenReissue(kiku.WithIdempotencyKey(ctx, "key"), kiku.PostTokenReissueParam{})
+				return err
+			},
+			attempts: 1,
+		},
+	}
+
+	for scenario, test := range tests {
+		var attempts int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&attempts, 1) <= test.failures {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			io.WriteString(w, `{"success":true,"response":{}}`)
+		}))
+		cli := kiku.NewClient(
+			kiku.WithBaseURL(ts.URL),
+			kiku.WithHTTPClient(ts.Client()),
+			kiku.WithLoginCompanyCode("foo"),
+			kiku.WithToken("bar"),
+			kiku.WithLogger(nil),
+			kiku.WithRetryPolicy(policy),
+		)
+		err := test.call(context.Background(), cli)
+		ts.Close()
+
+		assert.Equal(t, test.attempts, atomic.LoadInt32(&attempts), scenario)
+		assert.Equal(t, test.success, err == nil, scenario)
+	}
+}
+
+func Test_Client_Retry_ContextCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := cli.GetStaff(ctx, kiku.GetStaffParam{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
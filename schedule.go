@@ -0,0 +1,309 @@
+package kiku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// MaxBulkSchedules is the maximum number of schedules sent in one request of PUT Bulk Schedule API.
+const MaxBulkSchedules = 100
+
+// PlannedBreak is the struct representing a planned break (予定休憩).
+type PlannedBreak struct {
+	StartAt *AkTime `json:"start_at"` // 休憩開始予定日時
+	EndAt   *AkTime `json:"end_at"`   // 休憩終了予定日時
+}
+
+// Schedule is the struct representing the planned work of a day (勤務予定).
+type Schedule struct {
+	StaffID        int            `json:"staff_id,omitempty"`         // 従業員ID
+	Date           *AkTime        `json:"date"`                       // 勤務日
+	WorkPatternID  int            `json:"work_pattern_id,omitempty"`  // 勤務パターンID
+	PlannedStartAt *AkTime        `json:"planned_start_at,omitempty"` // 出勤予定日時
+	PlannedEndAt   *AkTime        `json:"planned_end_at,omitempty"`   // 退勤予定日時
+	PlannedBreaks  []PlannedBreak `json:"planned_breaks,omitempty"`   // 予定休憩の配列
+	Holiday        bool           `json:"holiday"`                    // 休日
+}
+
+// IsValid is the function to verify Schedule is correct.
+func (s Schedule) IsValid() (err error) {
+	switch {
+	case s.Date == nil:
+		err = errors.New("Date must be set")
+	case s.PlannedStartAt == nil && s.PlannedEndAt != nil:
+		err = errors.New("PlannedStartAt must be set")
+	case s.PlannedStartAt != nil && s.PlannedEndAt == nil:
+		err = errors.New("PlannedEndAt must be set")
+	case s.PlannedStartAt != nil && !s.PlannedEndAt.After(s.PlannedStartAt.Time):
+		err = errors.New("PlannedEndAt must be after PlannedStartAt")
+	case !s.Holiday && s.WorkPatternID == 0 && s.PlannedStartAt == nil:
+		err = errors.New("WorkPatternID or PlannedStartAt must be set for a working day")
+	}
+	if err != nil {
+		return
+	}
+
+	for i, b := range s.PlannedBreaks {
+		switch {
+		case b.StartAt == nil || b.EndAt == nil:
+			err = fmt.Errorf("PlannedBreaks[%d]: StartAt and EndAt must be set", i)
+		case !b.EndAt.After(b.StartAt.Time):
+			err = fmt.Errorf("PlannedBreaks[%d]: EndAt must be after StartAt", i)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// GetSchedulesParam is the struct for the request parameters of GET Schedule API.
+type GetSchedulesParam struct {
+	LoginCompanyCode string     // AKASHI企業ID
+	Token            string     // アクセストークン
+	StaffID          int        // 取得対象の従業員ID
+	StartDate        *time.Time // 取得期間の開始日
+	EndDate          *time.Time // 取得期間の終了日
+}
+
+// IsValid is the function to verify GetSchedulesParam is correct.
+func (g GetSchedulesParam) IsValid() (err error) {
+	switch {
+	case g.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case g.Token == "":
+		err = errors.New("Token must be set")
+	case g.StaffID == 0:
+		err = errors.New("StaffID must be set")
+	case g.StartDate == nil:
+		err = errors.New("StartDate must be set")
+	case g.EndDate == nil:
+		err = errors.New("EndDate must be set")
+	case g.EndDate.Before(*g.StartDate):
+		err = errors.New("EndDate must not be before StartDate")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of GET Schedule API.
+func (g GetSchedulesParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/schedules/%d", g.LoginCompanyCode, g.StaffID)
+
+	uv := url.Values{}
+	uv.Add("token", g.Token)
+	if g.StartDate != nil {
+		uv.Add("start_date", g.StartDate.In(Location).Format(DateFormat))
+	}
+	if g.EndDate != nil {
+		uv.Add("end_date", g.EndDate.In(Location).Format(DateFormat))
+	}
+	encodedURL += "?" + uv.Encode()
+	return
+}
+
+// GetSchedulesResponse is the struct representing the response of GET Schedule API.
+type GetSchedulesResponse struct {
+	LoginCompanyCode string     `json:"login_company_code"` // AKASHI企業ID
+	StaffID          int        `json:"staff_id"`           // 従業員ID
+	Count            int        `json:"count"`              // 勤務予定数
+	Schedules        []Schedule `json:"schedules"`          // 勤務予定の配列
+}
+
+// Decode is the function that decodes the response of GET Schedule API.
+func (g *GetSchedulesResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, g)
+}
+
+// GetSchedules is the function that retrieves schedules of an employee from AKASHI.
+func GetSchedules(ctx context.Context, param GetSchedulesParam) (response GetSchedulesResponse, err error) {
+	return defaultClient.GetSchedules(ctx, param)
+}
+
+// GetSchedules is the method that retrieves schedules of an employee from AKASHI.
+func (c *Client) GetSchedules(ctx context.Context, param GetSchedulesParam) (response GetSchedulesResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "GetSchedules", param.LoginCompanyCode, param.StaffID)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.get(ctx, param.LoginCompanyCode, param.EncodeURL())
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
+
+// PutSchedulesParam is the struct for the request parameters of PUT Schedule API.
+type PutSchedulesParam struct {
+	LoginCompanyCode string     `json:"-"`         // AKASHI企業ID
+	Token            string     `json:"token"`     // アクセストークン
+	StaffID          int        `json:"-"`         // 登録対象の従業員ID
+	Schedules        []Schedule `json:"schedules"` // 登録する勤務予定の配列
+}
+
+// IsValid is the function to verify PutSchedulesParam is correct.
+func (p PutSchedulesParam) IsValid() (err error) {
+	switch {
+	case p.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case p.Token == "":
+		err = errors.New("Token must be set")
+	case p.StaffID == 0:
+		err = errors.New("StaffID must be set")
+	case len(p.Schedules) == 0:
+		err = errors.New("Schedules must be set")
+	}
+	if err != nil {
+		return
+	}
+
+	for i, s := range p.Schedules {
+		if err = s.IsValid(); err != nil {
+			return fmt.Errorf("Schedules[%d]: %w", i, err)
+		}
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of PUT Schedule API.
+func (p PutSchedulesParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/schedules/%d", p.LoginCompanyCode, p.StaffID)
+	return
+}
+
+// PutSchedulesResponse is the struct representing the response of PUT Schedule API and PUT Bulk Schedule API.
+type PutSchedulesResponse struct {
+	LoginCompanyCode string `json:"login_company_code"` // AKASHI企業ID
+	Count            int    `json:"count"`              // 登録された勤務予定数
+}
+
+// Decode is the function that decodes PutSchedulesResponse.
+func (p *PutSchedulesResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, p)
+}
+
+// PutSchedules is the function that registers schedules of an employee to AKASHI.
+func PutSchedules(ctx context.Context, param PutSchedulesParam) (response PutSchedulesResponse, err error) {
+	return defaultClient.PutSchedules(ctx, param)
+}
+
+// PutSchedules is the method that registers schedules of an employee to AKASHI.
+// Existing schedules on the same dates are overwritten.
+func (c *Client) PutSchedules(ctx context.Context, param PutSchedulesParam) (response PutSchedulesResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "PutSchedules", param.LoginCompanyCode, param.StaffID)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.put(ctx, param.LoginCompanyCode, param.EncodeURL(), param)
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
+
+// PutBulkSchedulesParam is the struct for the request parameters of PUT Bulk Schedule API.
+type PutBulkSchedulesParam struct {
+	LoginCompanyCode string     `json:"-"`         // AKASHI企業ID
+	Token            string     `json:"token"`     // アクセストークン
+	Schedules        []Schedule `json:"schedules"` // 登録する勤務予定の配列(StaffIDが必須)
+}
+
+// IsValid is the function to verify PutBulkSchedulesParam is correct.
+func (p PutBulkSchedulesParam) IsValid() (err error) {
+	switch {
+	case p.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case p.Token == "":
+		err = errors.New("Token must be set")
+	case len(p.Schedules) == 0:
+		err = errors.New("Schedules must be set")
+	}
+	if err != nil {
+		return
+	}
+
+	for i, s := range p.Schedules {
+		if s.StaffID == 0 {
+			return fmt.Errorf("Schedules[%d]: StaffID must be set", i)
+		}
+		if err = s.IsValid(); err != nil {
+			return fmt.Errorf("Schedules[%d]: %w", i, err)
+		}
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of PUT Bulk Schedule API.
+func (p PutBulkSchedulesParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/schedules", p.LoginCompanyCode)
+	return
+}
+
+// PutBulkSchedules is the function that registers schedules of many employees to AKASHI.
+func PutBulkSchedules(ctx context.Context, param PutBulkSchedulesParam) (response PutSchedulesResponse, err error) {
+	return defaultClient.PutBulkSchedules(ctx, param)
+}
+
+// PutBulkSchedules is the method that registers schedules of many employees to AKASHI.
+// Schedules are sent in batches of MaxBulkSchedules. When a batch fails, the response counts
+// the schedules registered by the preceding batches.
+func (c *Client) PutBulkSchedules(ctx context.Context, param PutBulkSchedulesParam) (response PutSchedulesResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "PutBulkSchedules", param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	response.LoginCompanyCode = param.LoginCompanyCode
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		for len(param.Schedules) > response.Count {
+			batch := param
+			batch.Schedules = param.Schedules[response.Count:]
+			if len(batch.Schedules) > MaxBulkSchedules {
+				batch.Schedules = batch.Schedules[:MaxBulkSchedules]
+			}
+
+			var r PutSchedulesResponse
+			if r, err = c.putBulkSchedules(ctx, batch); err != nil {
+				return
+			}
+			response.Count += len(batch.Schedules)
+			if r.LoginCompanyCode != "" {
+				response.LoginCompanyCode = r.LoginCompanyCode
+			}
+		}
+		return
+	})
+	return
+}
+
+func (c *Client) putBulkSchedules(ctx context.Context, param PutBulkSchedulesParam) (response PutSchedulesResponse, err error) {
+	res, err := c.put(ctx, param.LoginCompanyCode, param.EncodeURL(), param)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	err = decodeResponse(res, response.Decode)
+	return
+}
@@ -0,0 +1,133 @@
+package kiku_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Schedule_IsValid(t *testing.T) {
+	at := func(hour int) *kiku.AkTime {
+		return &kiku.AkTime{Time: time.Date(2000, time.January, 2, hour, 0, 0, 0, time.UTC)}
+	}
+	tests := map[string]struct {
+		s   kiku.Schedule
+		err error
+	}{
+		"Work pattern": {
+			s: kiku.Schedule{Date: at(0), WorkPatternID: 1},
+		},
+		"Planned time with break": {
+			s: kiku.Schedule{Date: at(0), PlannedStartAt: at(9), PlannedEndAt: at(18), PlannedBreaks: []kiku.PlannedBreak{{StartAt: at(12), EndAt: at(13)}}},
+		},
+		"Holiday": {
+			s: kiku.Schedule{Date: at(0), Holiday: true},
+		},
+		"Date is not set": {
+			s:   kiku.Schedule{WorkPatternID: 1},
+			err: errors.New("Date must be set"),
+		},
+		"PlannedEndAt is not set": {
+			s:   kiku.Schedule{Date: at(0), PlannedStartAt: at(9)},
+			err: errors.New("PlannedEndAt must be set"),
+		},
+		"PlannedEndAt is before PlannedStartAt": {
+			s:   kiku.Schedule{Date: at(0), PlannedStartAt: at(18), PlannedEndAt: at(9)},
+			err: errors.New("PlannedEndAt must be after PlannedStartAt"),
+		},
+		"Working day without plan": {
+			s:   kiku.Schedule{Date: at(0)},
+			err: errors.New("WorkPatternID or PlannedStartAt must be set for a working day"),
+		},
+		"Break is reversed": {
+			s:   kiku.Schedule{Date: at(0), WorkPatternID: 1, PlannedBreaks: []kiku.PlannedBreak{{StartAt: at(13), EndAt: at(12)}}},
+			err: errors.New("PlannedBreaks[0]: EndAt must be after StartAt"),
+		},
+	}
+
+	for scenario, test := range tests {
+		err := test.s.IsValid()
+		switch test.err {
+		case nil:
+			assert.NoError(t, err, scenario)
+		default:
+			assert.EqualError(t, err, test.err.Error(), scenario)
+		}
+	}
+}
+
+func Test_PutBulkSchedulesParam_IsValid(t *testing.T) {
+	date := &kiku.AkTime{Time: time.Date(2000, time.January, 2, 0, 0, 0, 0, time.UTC)}
+	tests := map[string]struct {
+		p   kiku.PutBulkSchedulesParam
+		err string
+	}{
+		"Necessary parameter set": {
+			p: kiku.PutBulkSchedulesParam{LoginCompanyCode: "foo", Token: "bar", Schedules: []kiku.Schedule{{StaffID: 1, Date: date, Holiday: true}}},
+		},
+		"Schedules is empty": {
+			p:   kiku.PutBulkSchedulesParam{LoginCompanyCode: "foo", Token: "bar"},
+			err: "Schedules must be set",
+		},
+		"StaffID is not set": {
+			p:   kiku.PutBulkSchedulesParam{LoginCompanyCode: "foo", Token: "bar", Schedules: []kiku.Schedule{{StaffID: 1, Date: date, Holiday: true}, {Date: date, Holiday: true}}},
+			err: "Schedules[1]: StaffID must be set",
+		},
+		"Schedule is invalid": {
+			p:   kiku.PutBulkSchedulesParam{LoginCompanyCode: "foo", Token: "bar", Schedules: []kiku.Schedule{{StaffID: 1, Holiday: true}}},
+			err: "Schedules[0]: Date must be set",
+		},
+	}
+
+	for scenario, test := range tests {
+		err := test.p.IsValid()
+		switch test.err {
+		case "":
+			assert.NoError(t, err, scenario)
+		default:
+			assert.EqualError(t, err, test.err, scenario)
+		}
+	}
+}
+
+func Test_Client_PutBulkSchedules(t *testing.T) {
+	var sizes []int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Schedules []json.RawMessage `json:"schedules"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		sizes = append(sizes, len(body.Schedules))
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/foo/schedules", r.URL.Path)
+		fmt.Fprintf(w, `{"success":true,"response":{"login_company_code":"foo","count":%d}}`, len(body.Schedules))
+	}))
+	defer ts.Close()
+
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+	)
+
+	date := &kiku.AkTime{Time: time.Date(2000, time.January, 2, 0, 0, 0, 0, time.UTC)}
+	schedules := make([]kiku.Schedule, 250)
+	for i := range schedules {
+		schedules[i] = kiku.Schedule{StaffID: i + 1, Date: date, Holiday: true}
+	}
+
+	actual, err := cli.PutBulkSchedules(context.Background(), kiku.PutBulkSchedulesParam{Schedules: schedules})
+	assert.NoError(t, err)
+	assert.Equal(t, kiku.PutSchedulesResponse{LoginCompanyCode: "foo", Count: 250}, actual)
+	assert.Equal(t, []int{100, 100, 50}, sizes)
+}
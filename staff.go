@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"net/url"
 	"strconv"
 )
@@ -38,9 +37,9 @@ type EmploymentCategory struct {
 
 // PermissionGroup is the struct representing authorisation information.
 type PermissionGroup struct {
-	ID   int    `json:"permissionGroupId"` // 権限グループID
-	Type int    `json:"permissionType"`    // 権限種別(1:企業管理者,2:一般管理者,3:従業員)
-	Name string `json:"name"`              // 権限グループ名
+	ID   int            `json:"permissionGroupId"` // 権限グループID
+	Type PermissionType `json:"permissionType"`    // 権限種別
+	Name string         `json:"name"`              // 権限グループ名
 }
 
 // GetStaffParam is the struct for the request parameters of GET Employee API.
@@ -100,12 +99,11 @@ func (g *GetStaffResponse) DecodeFrom(r io.Reader) (err error) {
 		Errors   []Error          `json:"errors"`
 	}
 	if err = json.NewDecoder(r).Decode(&decoded); err != nil {
-		e := err.(*json.UnmarshalTypeError)
-		err = fmt.Errorf("Unmarshal error: field: %s, value: %s", e.Field, e.Value)
+		err = decodeError(err)
 		return
 	}
 	if !decoded.Success {
-		err = errors.New("AKASHI API failed")
+		err = &APIError{Errors: decoded.Errors}
 	}
 	*g = decoded.Response
 	return
@@ -113,22 +111,248 @@ func (g *GetStaffResponse) DecodeFrom(r io.Reader) (err error) {
 
 // GetStaff is the function that retrieves employee information from AKASHI.
 func GetStaff(ctx context.Context, param GetStaffParam) (response GetStaffResponse, err error) {
+	return defaultClient.GetStaff(ctx, param)
+}
+
+// GetStaff is the method that retrieves employee information from AKASHI.
+func (c *Client) GetStaff(ctx context.Context, param GetStaffParam) (response GetStaffResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "GetStaff", param.LoginCompanyCode, intValue(param.StaffID))
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		response, err = c.getStaff(ctx, param)
+		return
+	})
+	return
+}
+
+func (c *Client) getStaff(ctx context.Context, param GetStaffParam) (response GetStaffResponse, err error) {
 	endpointURL, err := param.EncodeURL()
 	if err != nil {
 		return
 	}
 
-	cli := newClient()
-	res, err := cli.Get(ctx, endpointURL)
-	defer res.Body.Close()
+	res, err := c.get(ctx, param.LoginCompanyCode, endpointURL)
 	if err != nil {
 		return
 	}
-	if res.StatusCode != http.StatusOK {
-		err = fmt.Errorf("Status code=%d", res.StatusCode)
-		return
+	defer res.Body.Close()
+
+	err = decodeResponse(res, response.DecodeFrom)
+	return
+}
+
+// CreateStaffParam is the struct for the request parameters of POST Employee API.
+type CreateStaffParam struct {
+	LoginCompanyCode     string `json:"-"`                              // AKASHI企業ID
+	Token                string `json:"token"`                          // アクセストークン
+	LastName             string `json:"lastName"`                       // 姓
+	FirstName            string `json:"firstName"`                      // 名
+	LastNameKana         string `json:"lastNameKana,omitempty"`         // カナ(姓)
+	FirstNameKana        string `json:"firstNameKana,omitempty"`        // カナ(名)
+	OrganizationID       int    `json:"organizationId,omitempty"`       // 組織ID(メイン)
+	EmploymentCategoryID int    `json:"employmentCategoryId,omitempty"` // 雇用区分ID
+	Tag                  string `json:"tag,omitempty"`                  // タグ
+	StaffNum             string `json:"staffNum,omitempty"`             // 従業員番号
+	IDmNum               string `json:"idmNum,omitempty"`               // IDm番号
+	CardTypeID           int    `json:"cardTypeId,omitempty"`           // カード種別
+	Remarks              string `json:"remarks,omitempty"`              // 備考
+	PermissionGroupID    int    `json:"permissionGroupId,omitempty"`    // 権限グループID
+}
+
+// IsValid is the function to verify CreateStaffParam is correct.
+func (p CreateStaffParam) IsValid() (err error) {
+	switch {
+	case p.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case p.Token == "":
+		err = errors.New("Token must be set")
+	case p.LastName == "":
+		err = errors.New("LastName must be set")
+	case p.FirstName == "":
+		err = errors.New("FirstName must be set")
 	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of POST Employee API.
+func (p CreateStaffParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/staffs", p.LoginCompanyCode)
+	return
+}
+
+// CreateStaffResponse is the struct representing the response of POST Employee API.
+type CreateStaffResponse struct {
+	LoginCompanyCode string `json:"login_company_code"` // AKASHI企業ID
+	StaffID          int    `json:"staffId"`            // 登録された従業員ID
+}
+
+// Decode is the function that decodes the response of POST Employee API.
+func (p *CreateStaffResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, p)
+}
+
+// CreateStaff is the function that registers an employee to AKASHI.
+func CreateStaff(ctx context.Context, param CreateStaffParam) (response CreateStaffResponse, err error) {
+	return defaultClient.CreateStaff(ctx, param)
+}
+
+// CreateStaff is the method that registers an employee to AKASHI.
+func (c *Client) CreateStaff(ctx context.Context, param CreateStaffParam) (response CreateStaffResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "CreateStaff", param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.post(ctx, param.LoginCompanyCode, param.EncodeURL(), param)
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
+
+// UpdateStaffParam is the struct for the request parameters of PATCH Employee API.
+// Only non-nil fields are updated.
+type UpdateStaffParam struct {
+	LoginCompanyCode     string  `json:"-"`                              // AKASHI企業ID
+	Token                string  `json:"token"`                          // アクセストークン
+	StaffID              int     `json:"-"`                              // 更新対象の従業員ID
+	LastName             *string `json:"lastName,omitempty"`             // 姓
+	FirstName            *string `json:"firstName,omitempty"`            // 名
+	LastNameKana         *string `json:"lastNameKana,omitempty"`         // カナ(姓)
+	FirstNameKana        *string `json:"firstNameKana,omitempty"`        // カナ(名)
+	OrganizationID       *int    `json:"organizationId,omitempty"`       // 組織ID(メイン)
+	EmploymentCategoryID *int    `json:"employmentCategoryId,omitempty"` // 雇用区分ID
+	Tag                  *string `json:"tag,omitempty"`                  // タグ
+	StaffNum             *string `json:"staffNum,omitempty"`             // 従業員番号
+	IDmNum               *string `json:"idmNum,omitempty"`               // IDm番号
+	CardTypeID           *int    `json:"cardTypeId,omitempty"`           // カード種別
+	Remarks              *string `json:"remarks,omitempty"`              // 備考
+	PermissionGroupID    *int    `json:"permissionGroupId,omitempty"`    // 権限グループID
+}
+
+// IsValid is the function to verify UpdateStaffParam is correct.
+func (p UpdateStaffParam) IsValid() (err error) {
+	switch {
+	case p.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case p.Token == "":
+		err = errors.New("Token must be set")
+	case p.StaffID == 0:
+		err = errors.New("StaffID must be set")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of PATCH Employee API.
+func (p UpdateStaffParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/staffs/%d", p.LoginCompanyCode, p.StaffID)
+	return
+}
+
+// UpdateStaffResponse is the struct representing the response of PATCH Employee API.
+type UpdateStaffResponse struct {
+	LoginCompanyCode string `json:"login_company_code"` // AKASHI企業ID
+	Staff            Staff  `json:"staff"`              // 更新後の従業員情報
+}
+
+// Decode is the function that decodes the response of PATCH Employee API.
+func (p *UpdateStaffResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, p)
+}
+
+// UpdateStaff is the function that partially updates an employee in AKASHI.
+func UpdateStaff(ctx context.Context, param UpdateStaffParam) (response UpdateStaffResponse, err error) {
+	return defaultClient.UpdateStaff(ctx, param)
+}
+
+// UpdateStaff is the method that partially updates an employee in AKASHI.
+func (c *Client) UpdateStaff(ctx context.Context, param UpdateStaffParam) (response UpdateStaffResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "UpdateStaff", param.LoginCompanyCode, param.StaffID)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.patch(ctx, param.LoginCompanyCode, param.EncodeURL(), param)
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
+
+// DeleteStaffParam is the struct for the request parameters of DELETE Employee API.
+type DeleteStaffParam struct {
+	LoginCompanyCode string `json:"-"`     // AKASHI企業ID
+	Token            string `json:"token"` // アクセストークン
+	StaffID          int    `json:"-"`     // 削除対象の従業員ID
+}
+
+// IsValid is the function to verify DeleteStaffParam is correct.
+func (p DeleteStaffParam) IsValid() (err error) {
+	switch {
+	case p.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case p.Token == "":
+		err = errors.New("Token must be set")
+	case p.StaffID == 0:
+		err = errors.New("StaffID must be set")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of DELETE Employee API.
+func (p DeleteStaffParam) EncodeURL() (encodedURL string) {
+	encodedURL = fmt.Sprintf("/%s/staffs/%d", p.LoginCompanyCode, p.StaffID)
+	return
+}
+
+// DeleteStaffResponse is the struct representing the response of DELETE Employee API.
+type DeleteStaffResponse struct {
+	LoginCompanyCode string `json:"login_company_code"` // AKASHI企業ID
+	StaffID          int    `json:"staffId"`            // 削除された従業員ID
+}
+
+// Decode is the function that decodes the response of DELETE Employee API.
+func (p *DeleteStaffResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, p)
+}
+
+// DeleteStaff is the function that deletes an employee from AKASHI.
+func DeleteStaff(ctx context.Context, param DeleteStaffParam) (response DeleteStaffResponse, err error) {
+	return defaultClient.DeleteStaff(ctx, param)
+}
+
+// DeleteStaff is the method that deletes an employee from AKASHI.
+func (c *Client) DeleteStaff(ctx context.Context, param DeleteStaffParam) (response DeleteStaffResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "DeleteStaff", param.LoginCompanyCode, param.StaffID)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.delete(ctx, param.LoginCompanyCode, param.EncodeURL(), param)
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
 
-	err = response.DecodeFrom(res.Body)
+		return decodeResponse(res, response.Decode)
+	})
 	return
 }
@@ -0,0 +1,139 @@
+package kiku
+
+import "context"
+
+// StaffIterator is the iterator walking every page of GET Employee API.
+// A StaffIterator must not be used by multiple goroutines concurrently.
+type StaffIterator struct {
+	client   *Client
+	param    GetStaffParam
+	prefetch bool
+
+	page     int
+	pending  chan staffPage
+	staffs   []Staff
+	index    int
+	current  Staff
+	received int
+	progress StaffIteratorProgress
+	done     bool
+	err      error
+}
+
+// StaffIteratorProgress is the struct representing the progress of StaffIterator.
+type StaffIteratorProgress struct {
+	Pages      int // 取得済みのページ数
+	Count      int // これまでに返した従業員数
+	TotalCount int // 取得することができる従業員数
+}
+
+type staffPage struct {
+	response GetStaffResponse
+	err      error
+}
+
+// NewStaffIterator returns the iterator over all employees under param.
+// param.StaffID and param.Page are ignored. When prefetch is true, the next page is requested
+// concurrently while the current page is consumed.
+func (c *Client) NewStaffIterator(param GetStaffParam, prefetch bool) *StaffIterator {
+	param.StaffID = nil
+	param.Page = nil
+	return &StaffIterator{
+		client:   c,
+		param:    param,
+		prefetch: prefetch,
+	}
+}
+
+// Next advances the iterator to the next employee.
+// It returns false when all employees have been returned, an error occurred or ctx is done.
+func (it *StaffIterator) Next(ctx context.Context) bool {
+	for {
+		if it.err != nil {
+			return false
+		}
+		if it.err = ctx.Err(); it.err != nil {
+			return false
+		}
+		if it.index < len(it.staffs) {
+			it.current = it.staffs[it.index]
+			it.index++
+			it.progress.Count++
+			return true
+		}
+		if it.done {
+			return false
+		}
+
+		p := it.fetch(ctx)
+		if it.err = p.err; it.err != nil {
+			return false
+		}
+		it.staffs = p.response.Staffs
+		it.index = 0
+		it.received += len(p.response.Staffs)
+		it.progress.Pages++
+		it.progress.TotalCount = p.response.TotalCount
+		if len(p.response.Staffs) == 0 || it.received >= p.response.TotalCount {
+			it.done = true
+		} else if it.prefetch {
+			it.pending = it.start(ctx)
+		}
+	}
+}
+
+// fetch returns the next page, waiting for the prefetched one if it is in flight.
+func (it *StaffIterator) fetch(ctx context.Context) staffPage {
+	pending := it.pending
+	it.pending = nil
+	if pending == nil {
+		pending = it.start(ctx)
+	}
+
+	select {
+	case p := <-pending:
+		return p
+	case <-ctx.Done():
+		return staffPage{err: ctx.Err()}
+	}
+}
+
+// start requests the next page in a new goroutine.
+func (it *StaffIterator) start(ctx context.Context) chan staffPage {
+	it.page++
+	page := it.page
+	param := it.param
+	param.Page = &page
+
+	ch := make(chan staffPage, 1)
+	go func() {
+		res, err := it.client.GetStaff(ctx, param)
+		ch <- staffPage{response: res, err: err}
+	}()
+	return ch
+}
+
+// Staff returns the current employee.
+func (it *StaffIterator) Staff() Staff {
+	return it.current
+}
+
+// Err returns the error which stopped the iteration.
+func (it *StaffIterator) Err() error {
+	return it.err
+}
+
+// Progress returns the progress of the iteration.
+func (it *StaffIterator) Progress() StaffIteratorProgress {
+	return it.progress
+}
+
+// ListAllStaff is the method that retrieves all employees under param by walking every page.
+func (c *Client) ListAllStaff(ctx context.Context, param GetStaffParam) (staffs []Staff, err error) {
+	it := c.NewStaffIterator(param, true)
+	for it.Next(ctx) {
+		staffs = append(staffs, it.Staff())
+	}
+	err = it.Err()
+	return
+}
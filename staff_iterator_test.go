@@ -0,0 +1,133 @@
+package kiku_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+// newStaffServer returns the server which pages total employees by size.
+func newStaffServer(total, size int, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		var staffs []string
+		for id := (page-1)*size + 1; id <= page*size && id <= total; id++ {
+			staffs = append(staffs, fmt.Sprintf(`{"staffId":%d}`, id))
+		}
+		fmt.Fprintf(w, `{"success":true,"response":{"Count":%d,"TotalCount":%d,"staffs":[%s]}}`,
+			len(staffs), total, strings.Join(staffs, ","))
+	}))
+}
+
+func Test_StaffIterator(t *testing.T) {
+	tests := map[string]struct {
+		total    int
+		size     int
+		prefetch bool
+		requests int32
+		pages    int
+	}{
+		"Multiple pages": {
+			total:    5,
+			size:     2,
+			requests: 3,
+			pages:    3,
+		},
+		"Multiple pages with prefetch": {
+			total:    5,
+			size:     2,
+			prefetch: true,
+			requests: 3,
+			pages:    3,
+		},
+		"Single page": {
+			total:    2,
+			size:     2,
+			prefetch: true,
+			requests: 1,
+			pages:    1,
+		},
+		"No employee": {
+			total:    0,
+			size:     2,
+			requests: 1,
+			pages:    1,
+		},
+	}
+
+	for scenario, test := range tests {
+		var requests int32
+		ts := newStaffServer(test.total, test.size, &requests)
+		cli := kiku.NewClient(
+			kiku.WithBaseURL(ts.URL),
+			kiku.WithHTTPClient(ts.Client()),
+			kiku.WithLoginCompanyCode("foo"),
+			kiku.WithToken("bar"),
+			kiku.WithLogger(nil),
+		)
+
+		it := cli.NewStaffIterator(kiku.GetStaffParam{}, test.prefetch)
+		var ids []int
+		for it.Next(context.Background()) {
+			ids = append(ids, it.Staff().ID)
+		}
+		ts.Close()
+
+		assert.NoError(t, it.Err(), scenario)
+		assert.Len(t, ids, test.total, scenario)
+		for i, id := range ids {
+			assert.Equal(t, i+1, id, scenario)
+		}
+		assert.Equal(t, test.requests, atomic.LoadInt32(&requests), scenario)
+		assert.Equal(t, kiku.StaffIteratorProgress{Pages: test.pages, Count: test.total, TotalCount: test.total}, it.Progress(), scenario)
+	}
+}
+
+func Test_StaffIterator_ContextCanceled(t *testing.T) {
+	var requests int32
+	ts := newStaffServer(5, 2, &requests)
+	defer ts.Close()
+
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := cli.NewStaffIterator(kiku.GetStaffParam{}, false)
+	assert.True(t, it.Next(ctx))
+	cancel()
+	assert.False(t, it.Next(ctx))
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+	assert.Equal(t, 1, it.Progress().Count)
+}
+
+func Test_Client_ListAllStaff(t *testing.T) {
+	var requests int32
+	ts := newStaffServer(7, 3, &requests)
+	defer ts.Close()
+
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+	)
+
+	staffs, err := cli.ListAllStaff(context.Background(), kiku.GetStaffParam{})
+	assert.NoError(t, err)
+	assert.Len(t, staffs, 7)
+}
@@ -1,9 +1,12 @@
 package kiku_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -144,7 +147,7 @@ func Test_GetStaffResponse_DecodeFrom(t *testing.T) {
 				"success":false
 			}`),
 			expect: &kiku.GetStaffResponse{},
-			err:    errors.New("AKASHI API failed"),
+			err:    &kiku.APIError{},
 		},
 	}
 
@@ -161,3 +164,124 @@ func Test_GetStaffResponse_DecodeFrom(t *testing.T) {
 		assert.Equal(t, test.expect, actual, scenario)
 	}
 }
+
+func Test_CreateStaffParam_IsValid(t *testing.T) {
+	tests := map[string]struct {
+		p   kiku.CreateStaffParam
+		err error
+	}{
+		"Necessary parameter set": {
+			p: kiku.CreateStaffParam{LoginCompanyCode: "foo", Token: "bar", LastName: "愛", FirstName: "上大"},
+		},
+		"LoginCompanyCode is not set": {
+			p:   kiku.CreateStaffParam{Token: "bar", LastName: "愛", FirstName: "上大"},
+			err: errors.New("LoginCompanyCode must be set"),
+		},
+		"Token is not set": {
+			p:   kiku.CreateStaffParam{LoginCompanyCode: "foo", LastName: "愛", FirstName: "上大"},
+			err: errors.New("Token must be set"),
+		},
+		"LastName is not set": {
+			p:   kiku.CreateStaffParam{LoginCompanyCode: "foo", Token: "bar", FirstName: "上大"},
+			err: errors.New("LastName must be set"),
+		},
+		"FirstName is not set": {
+			p:   kiku.CreateStaffParam{LoginCompanyCode: "foo", Token: "bar", LastName: "愛"},
+			err: errors.New("FirstName must be set"),
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.err, test.p.IsValid(), scenario)
+	}
+}
+
+func Test_UpdateStaffParam_IsValid(t *testing.T) {
+	tests := map[string]struct {
+		p   kiku.UpdateStaffParam
+		err error
+	}{
+		"Necessary parameter set": {
+			p: kiku.UpdateStaffParam{LoginCompanyCode: "foo", Token: "bar", StaffID: 1},
+		},
+		"StaffID is not set": {
+			p:   kiku.UpdateStaffParam{LoginCompanyCode: "foo", Token: "bar"},
+			err: errors.New("StaffID must be set"),
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.err, test.p.IsValid(), scenario)
+	}
+}
+
+func Test_DeleteStaffParam_IsValid(t *testing.T) {
+	tests := map[string]struct {
+		p   kiku.DeleteStaffParam
+		err error
+	}{
+		"Necessary parameter set": {
+			p: kiku.DeleteStaffParam{LoginCompanyCode: "foo", Token: "bar", StaffID: 1},
+		},
+		"Token is not set": {
+			p:   kiku.DeleteStaffParam{LoginCompanyCode: "foo", StaffID: 1},
+			err: errors.New("Token must be set"),
+		},
+		"StaffID is not set": {
+			p:   kiku.DeleteStaffParam{LoginCompanyCode: "foo", Token: "bar"},
+			err: errors.New("StaffID must be set"),
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.err, test.p.IsValid(), scenario)
+	}
+}
+
+func Test_Client_StaffWrite(t *testing.T) {
+	var (
+		method string
+		path   string
+		body   string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		io.WriteString(w, `{"success":true,"response":{"login_company_code":"foo","staffId":1,"staff":{"staffId":1,"tag":"new"}}}`)
+	}))
+	defer ts.Close()
+
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+	)
+	ctx := context.Background()
+	tag := "new"
+	org := 3
+
+	created, err := cli.CreateStaff(ctx, kiku.CreateStaffParam{LastName: "愛", FirstName: "上大"})
+	assert.NoError(t, err)
+	assert.Equal(t, kiku.CreateStaffResponse{LoginCompanyCode: "foo", StaffID: 1}, created)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/foo/staffs", path)
+	assert.JSONEq(t, `{"token":"bar","lastName":"愛","firstName":"上大"}`, body)
+
+	updated, err := cli.UpdateStaff(ctx, kiku.UpdateStaffParam{StaffID: 1, Tag: &tag, OrganizationID: &org})
+	assert.NoError(t, err)
+	assert.Equal(t, kiku.Staff{ID: 1, Tag: "new"}, updated.Staff)
+	assert.Equal(t, http.MethodPatch, method)
+	assert.Equal(t, "/foo/staffs/1", path)
+	assert.JSONEq(t, `{"token":"bar","tag":"new","organizationId":3}`, body)
+
+	deleted, err := cli.DeleteStaff(ctx, kiku.DeleteStaffParam{StaffID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, kiku.DeleteStaffResponse{LoginCompanyCode: "foo", StaffID: 1}, deleted)
+	assert.Equal(t, http.MethodDelete, method)
+	assert.Equal(t, "/foo/staffs/1", path)
+	assert.JSONEq(t, `{"token":"bar"}`, body)
+}
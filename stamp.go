@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -27,18 +28,67 @@ const (
 	ReturnDateFormat = "2006/01/02 15:04:05"
 )
 
+// Location is the time zone in which AkTime is parsed and formatted. It defaults to Asia/Tokyo.
+// Change it before using the package, since it is not guarded against concurrent access.
+var Location = loadLocation("Asia/Tokyo", 9*60*60)
+
+func loadLocation(name string, offset int) *time.Location {
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc
+	}
+	return time.FixedZone(name, offset)
+}
+
 // AkTime is the time for AKASHI.
+// It is encoded in DateFormat and decoded from either DateFormat or ReturnDateFormat in Location.
 type AkTime struct {
 	time.Time
 }
 
+// ParseAkTime parses v in DateFormat or ReturnDateFormat in Location.
+func ParseAkTime(v string) (a AkTime, err error) {
+	layout := ReturnDateFormat
+	if len(v) == len(DateFormat) {
+		layout = DateFormat
+	}
+	t, err := time.ParseInLocation(layout, v, Location)
+	if err != nil {
+		return
+	}
+	a = AkTime{t}
+	return
+}
+
+// MarshalText is the function that formats AkTime in DateFormat.
+func (a AkTime) MarshalText() ([]byte, error) {
+	return []byte(a.In(Location).Format(DateFormat)), nil
+}
+
+// UnmarshalText is the function that parses AkTime in DateFormat or ReturnDateFormat.
+func (a *AkTime) UnmarshalText(data []byte) (err error) {
+	*a, err = ParseAkTime(string(data))
+	return
+}
+
+// MarshalJSON is the function that extends marshalJSON.
+// The zero time is encoded as null.
+func (a AkTime) MarshalJSON() ([]byte, error) {
+	if a.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + a.In(Location).Format(DateFormat) + `"`), nil
+}
+
 // UnmarshalJSON is the function that extends unmarshalJSON.
 func (a *AkTime) UnmarshalJSON(data []byte) (err error) {
 	if string(data) == "null" {
 		return
 	}
-	t, err := time.Parse(`"`+ReturnDateFormat+`"`, string(data))
-	*a = AkTime{t}
+	v, err := strconv.Unquote(string(data))
+	if err != nil {
+		return fmt.Errorf("AkTime must be a string: %s", data)
+	}
+	*a, err = ParseAkTime(v)
 	return
 }
 
@@ -83,12 +133,12 @@ func (s StampType) String() string {
 
 // StampAttribute is the struct represents 打刻実績参照結果
 type StampAttribute struct {
-	Method      int     `json:"method"`       // 打刻方法
-	OrgID       int     `json:"org_id"`       // 組織ID
-	WorkplaceID int     `json:"workplace_id"` // 勤務地ID
-	Latitude    float32 `json:"latitude"`     // 緯度
-	Longitude   float32 `json:"longitude"`    // 経度
-	IP          string  `json:"ip"`           // 打刻機のIPアドレス
+	Method      StampMethod `json:"method"`       // 打刻方法
+	OrgID       int         `json:"org_id"`       // 組織ID
+	WorkplaceID int         `json:"workplace_id"` // 勤務地ID
+	Latitude    float32     `json:"latitude"`     // 緯度
+	Longitude   float32     `json:"longitude"`    // 経度
+	IP          string      `json:"ip"`           // 打刻機のIPアドレス
 }
 
 // GetStampParam is the struct represents for the request parameters of GET stamp API.
@@ -127,10 +177,10 @@ func (g GetStampParam) EncodeURL() (encodedURL string) {
 		uv.Add("token", g.Token)
 	}
 	if g.StartDate != nil {
-		uv.Add("start_date", g.StartDate.Format(DateFormat))
+		uv.Add("start_date", g.StartDate.In(Location).Format(DateFormat))
 	}
 	if g.EndDate != nil {
-		uv.Add("end_date", g.EndDate.Format(DateFormat))
+		uv.Add("end_date", g.EndDate.In(Location).Format(DateFormat))
 	}
 	q := uv.Encode()
 	if q != "" {
@@ -158,11 +208,10 @@ func (g *GetStampResponse) Decode(r io.Reader) (err error) {
 
 	err = json.NewDecoder(r).Decode(&gsr)
 	switch {
-	case !gsr.Success:
-		err = errors.New("Requesting Stamps API failed")
-		fallthrough
 	case err != nil:
-		return
+		err = decodeError(err)
+	case !gsr.Success:
+		err = &APIError{Errors: gsr.Errors}
 	default:
 		*g = gsr.Response
 	}
@@ -171,23 +220,35 @@ func (g *GetStampResponse) Decode(r io.Reader) (err error) {
 
 // GetStamps is the function that retrieves stamp information from AKASHI.
 func GetStamps(ctx context.Context, param GetStampParam) (response GetStampResponse, err error) {
+	return defaultClient.GetStamps(ctx, param)
+}
+
+// GetStamps is the method that retrieves stamp information from AKASHI.
+func (c *Client) GetStamps(ctx context.Context, param GetStampParam) (response GetStampResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "GetStamps", param.LoginCompanyCode, param.StaffID)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		response, err = c.getStamps(ctx, param)
+		return
+	})
+	return
+}
+
+func (c *Client) getStamps(ctx context.Context, param GetStampParam) (response GetStampResponse, err error) {
 	if err = param.IsValid(); err != nil {
 		return
 	}
 
 	endpoint := param.EncodeURL()
 
-	cli := newClient()
-	res, err := cli.Get(ctx, endpoint)
-	switch {
-	case res.StatusCode != http.StatusOK:
-		err = fmt.Errorf("Status code=%d", res.StatusCode)
-		fallthrough
-	case err != nil:
+	res, err := c.get(ctx, param.LoginCompanyCode, endpoint)
+	if err != nil {
 		return
 	}
+	defer res.Body.Close()
 
-	err = response.Decode(res.Body)
+	err = decodeResponse(res, response.Decode)
 
 	return
 }
@@ -231,11 +292,10 @@ func (p *PostStampResponse) Decode(r io.Reader) (err error) {
 
 	err = json.NewDecoder(r).Decode(&psr)
 	switch {
-	case !psr.Success:
-		err = errors.New("Requesting Stamp API failed")
-		fallthrough
 	case err != nil:
-		return
+		err = decodeError(err)
+	case !psr.Success:
+		err = &APIError{Errors: psr.Errors}
 	default:
 		*p = psr.Response
 	}
@@ -243,6 +303,22 @@ func (p *PostStampResponse) Decode(r io.Reader) (err error) {
 }
 
 func PostStamp(ctx context.Context, param PostStampParam) (response PostStampResponse, err error) {
+	return defaultClient.PostStamp(ctx, param)
+}
+
+// PostStamp is the method that registers a stamp to AKASHI.
+func (c *Client) PostStamp(ctx context.Context, param PostStampParam) (response PostStampResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "PostStamp", param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		response, err = c.postStamp(ctx, param)
+		return
+	})
+	return
+}
+
+func (c *Client) postStamp(ctx context.Context, param PostStampParam) (response PostStampResponse, err error) {
 	if err = param.IsValid(); err != nil {
 		return
 	}
@@ -252,18 +328,23 @@ func PostStamp(ctx context.Context, param PostStampParam) (response PostStampRes
 		return
 	}
 
-	cli := newClient()
-	res, err := cli.Post(ctx, endpoint, param)
-	switch {
-	case res.StatusCode != http.StatusOK:
-		err = fmt.Errorf("Status code=%d", res.StatusCode)
-		fallthrough
-	case err != nil:
+	_, retryable := idempotencyKeyFrom(ctx)
+	res, err := c.do(ctx, request{
+		method:      http.MethodPost,
+		path:        endpoint,
+		body:        param,
+		companyCode: param.LoginCompanyCode,
+		retryable:   retryable,
+	})
+	if err != nil {
 		return
 	}
+	defer res.Body.Close()
 
-	err = response.Decode(res.Body)
-	fmt.Printf("response: %+v\n", response)
+	if err = decodeResponse(res, response.Decode); err != nil {
+		return
+	}
+	c.logger.Debug("kiku: stamp registered", "staff_id", response.StaffID, "type", response.Type, "stamped_at", response.StampedAt)
 
 	return
 }
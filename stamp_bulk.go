@@ -0,0 +1,196 @@
+package kiku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultStampConcurrency is the number of employees whose stamps GetStampsForStaffs fetches at once by default.
+const DefaultStampConcurrency = 4
+
+// GetStampsForStaffsParam is the struct for the parameters of GetStampsForStaffs.
+type GetStampsForStaffsParam struct {
+	LoginCompanyCode string     // AKASHI企業ID
+	Token            string     // アクセストークン
+	StartDate        *time.Time // 打刻取得期間の開始日時
+	EndDate          *time.Time // 打刻取得期間の終了日時
+	StaffIDs         []int      // 取得対象の従業員IDの配列(空の場合は管理下の全従業員)
+	Concurrency      int        // 同時に取得する従業員数(0の場合はDefaultStampConcurrency)
+}
+
+// IsValid is the function to verify GetStampsForStaffsParam is correct.
+// Token is verified for each employee, since it may be supplied by TokenSource.
+func (g GetStampsForStaffsParam) IsValid() (err error) {
+	switch {
+	case g.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case g.StartDate == nil:
+		err = errors.New("StartDate must be set")
+	case g.EndDate == nil:
+		err = errors.New("EndDate must be set")
+	case g.Concurrency < 0:
+		err = errors.New("Concurrency must not be negative")
+	}
+	return
+}
+
+// StaffError is the error occurred while processing an employee.
+type StaffError struct {
+	StaffID int   // 従業員ID
+	Err     error // 発生したエラー
+}
+
+func (e *StaffError) Error() string {
+	return fmt.Sprintf("staff %d: %v", e.StaffID, e.Err)
+}
+
+func (e *StaffError) Unwrap() error {
+	return e.Err
+}
+
+// StaffErrors is the errors of many employees. errors.Is and errors.As match any of them.
+type StaffErrors []*StaffError
+
+func (e StaffErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e StaffErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e StaffErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStampsForStaffsResponse is the struct representing the result of GetStampsForStaffs.
+type GetStampsForStaffsResponse struct {
+	Stamps map[int]GetStampResponse // 従業員IDごとの打刻
+	Errors map[int]error            // 従業員IDごとのエラー
+}
+
+// Err returns the errors of all employees as StaffErrors in order of StaffID, or nil if there is none.
+func (g GetStampsForStaffsResponse) Err() error {
+	if len(g.Errors) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(g.Errors))
+	for id := range g.Errors {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	errs := make(StaffErrors, len(ids))
+	for i, id := range ids {
+		errs[i] = &StaffError{StaffID: id, Err: g.Errors[id]}
+	}
+	return errs
+}
+
+// GetStampsForStaffs is the function that retrieves the stamps of many employees concurrently from AKASHI.
+func GetStampsForStaffs(ctx context.Context, param GetStampsForStaffsParam) (response GetStampsForStaffsResponse, err error) {
+	return defaultClient.GetStampsForStaffs(ctx, param)
+}
+
+// GetStampsForStaffs is the method that retrieves the stamps of many employees concurrently from AKASHI.
+// An employee whose stamps cannot be retrieved is reported in response.Errors without failing the others.
+// err is returned only when the employees cannot be listed or ctx is done, in which case
+// the employees not yet retrieved are reported with ctx.Err().
+func (c *Client) GetStampsForStaffs(ctx context.Context, param GetStampsForStaffsParam) (response GetStampsForStaffsResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	if err = param.IsValid(); err != nil {
+		return
+	}
+
+	staffIDs := param.StaffIDs
+	if len(staffIDs) == 0 {
+		var staffs []Staff
+		staffs, err = c.ListAllStaff(ctx, GetStaffParam{LoginCompanyCode: param.LoginCompanyCode, Token: param.Token})
+		if err != nil {
+			return
+		}
+		for _, s := range staffs {
+			staffIDs = append(staffIDs, s.ID)
+		}
+	}
+
+	concurrency := param.Concurrency
+	if concurrency == 0 {
+		concurrency = DefaultStampConcurrency
+	}
+	if concurrency > len(staffIDs) {
+		concurrency = len(staffIDs)
+	}
+
+	response = GetStampsForStaffsResponse{
+		Stamps: make(map[int]GetStampResponse, len(staffIDs)),
+		Errors: map[int]error{},
+	}
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		jobs = make(chan int)
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				res, err := c.GetStamps(ctx, GetStampParam{
+					LoginCompanyCode: param.LoginCompanyCode,
+					Token:            param.Token,
+					StartDate:        param.StartDate,
+					EndDate:          param.EndDate,
+					StaffID:          id,
+				})
+
+				mu.Lock()
+				if err != nil {
+					response.Errors[id] = err
+				} else {
+					response.Stamps[id] = res
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i, id := range staffIDs {
+		select {
+		case jobs <- id:
+			continue
+		case <-ctx.Done():
+		}
+
+		mu.Lock()
+		for _, id := range staffIDs[i:] {
+			response.Errors[id] = ctx.Err()
+		}
+		mu.Unlock()
+		break
+	}
+	close(jobs)
+	wg.Wait()
+
+	err = ctx.Err()
+	return
+}
@@ -0,0 +1,186 @@
+package kiku_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+// newBulkStampServer returns the server which lists staffs 1 to total and returns a stamp for each of them.
+// The stamps of staff 3 are not found.
+func newBulkStampServer(total int, inFlight, maxInFlight *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/staffs") {
+			var staffs []string
+			for id := 1; id <= total; id++ {
+				staffs = append(staffs, fmt.Sprintf(`{"staffId":%d}`, id))
+			}
+			fmt.Fprintf(w, `{"success":true,"response":{"Count":%d,"TotalCount":%d,"staffs":[%s]}}`,
+				total, total, strings.Join(staffs, ","))
+			return
+		}
+
+		n := atomic.AddInt32(inFlight, 1)
+		defer atomic.AddInt32(inFlight, -1)
+		for {
+			m := atomic.LoadInt32(maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		id, _ := strconv.Atoi(path.Base(r.URL.Path))
+		if id == 3 {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"success":false,"errors":[{"code":"NOT_FOUND","message":"not found"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"success":true,"response":{"login_company_code":"foo","staff_id":%d,"count":1,`+
+			`"stamps":[{"stamped_at":"2000/01/01 09:00:00","type":11}]}}`, id)
+	}))
+}
+
+func Test_Client_GetStampsForStaffs(t *testing.T) {
+	start, end := time.Now(), time.Now()
+	tests := map[string]struct {
+		param       kiku.GetStampsForStaffsParam
+		stamps      []int
+		errors      []int
+		maxInFlight int32
+	}{
+		"Given staffs": {
+			param:       kiku.GetStampsForStaffsParam{StaffIDs: []int{1, 2, 3}, Concurrency: 2},
+			stamps:      []int{1, 2},
+			errors:      []int{3},
+			maxInFlight: 2,
+		},
+		"All staffs": {
+			param:       kiku.GetStampsForStaffsParam{},
+			stamps:      []int{1, 2, 4, 5, 6, 7, 8},
+			errors:      []int{3},
+			maxInFlight: kiku.DefaultStampConcurrency,
+		},
+	}
+
+	for scenario, test := range tests {
+		var inFlight, maxInFlight int32
+		ts := newBulkStampServer(8, &inFlight, &maxInFlight)
+		cli := kiku.NewClient(
+			kiku.WithBaseURL(ts.URL),
+			kiku.WithHTTPClient(ts.Client()),
+			kiku.WithLoginCompanyCode("foo"),
+			kiku.WithToken("bar"),
+			kiku.WithLogger(nil),
+		)
+
+		test.param.StartDate, test.param.EndDate = &start, &end
+		actual, err := cli.GetStampsForStaffs(context.Background(), test.param)
+		ts.Close()
+
+		assert.NoError(t, err, scenario)
+		assert.Len(t, actual.Stamps, len(test.stamps), scenario)
+		for _, id := range test.stamps {
+			assert.Equal(t, id, actual.Stamps[id].StaffID, scenario)
+			assert.Len(t, actual.Stamps[id].Stamps, 1, scenario)
+		}
+		assert.Len(t, actual.Errors, len(test.errors), scenario)
+		for _, id := range test.errors {
+			assert.ErrorIs(t, actual.Errors[id], kiku.ErrNotFound, scenario)
+		}
+		assert.LessOrEqual(t, maxInFlight, test.maxInFlight, scenario)
+
+		var staffErr *kiku.StaffError
+		assert.True(t, errors.As(actual.Err(), &staffErr), scenario)
+		assert.Equal(t, 3, staffErr.StaffID, scenario)
+		assert.ErrorIs(t, actual.Err(), kiku.ErrNotFound, scenario)
+	}
+}
+
+func Test_Client_GetStampsForStaffs_Canceled(t *testing.T) {
+	var inFlight, maxInFlight int32
+	ts := newBulkStampServer(0, &inFlight, &maxInFlight)
+	defer ts.Close()
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("bar"),
+		kiku.WithLogger(nil),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start, end := time.Now(), time.Now()
+	actual, err := cli.GetStampsForStaffs(ctx, kiku.GetStampsForStaffsParam{
+		StartDate: &start, EndDate: &end, StaffIDs: []int{1, 2, 4, 5}, Concurrency: 1,
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 4, len(actual.Stamps)+len(actual.Errors))
+	for _, e := range actual.Errors {
+		assert.ErrorIs(t, e, context.Canceled)
+	}
+}
+
+func Test_GetStampsForStaffsParam_IsValid(t *testing.T) {
+	date := time.Now()
+	tests := map[string]struct {
+		g   kiku.GetStampsForStaffsParam
+		err error
+	}{
+		"Normal scenario": {
+			g: kiku.GetStampsForStaffsParam{LoginCompanyCode: "foo", StartDate: &date, EndDate: &date},
+		},
+		"StartDate is not set": {
+			g:   kiku.GetStampsForStaffsParam{LoginCompanyCode: "foo", EndDate: &date},
+			err: errors.New("StartDate must be set"),
+		},
+		"Concurrency is negative": {
+			g:   kiku.GetStampsForStaffsParam{LoginCompanyCode: "foo", StartDate: &date, EndDate: &date, Concurrency: -1},
+			err: errors.New("Concurrency must not be negative"),
+		},
+	}
+
+	for scenario, test := range tests {
+		assert.Equal(t, test.err, test.g.IsValid(), scenario)
+	}
+}
+
+func Test_GetStampsForStaffsResponse_Err(t *testing.T) {
+	tests := map[string]struct {
+		errors map[int]error
+		expect string
+	}{
+		"No error": {},
+		"Errors are sorted by StaffID": {
+			errors: map[int]error{2: kiku.ErrNotFound, 1: errors.New("foo")},
+			expect: "staff 1: foo\nstaff 2: " + kiku.ErrNotFound.Error(),
+		},
+	}
+
+	for scenario, test := range tests {
+		err := kiku.GetStampsForStaffsResponse{Errors: test.errors}.Err()
+		if test.expect == "" {
+			assert.NoError(t, err, scenario)
+			continue
+		}
+		assert.EqualError(t, err, test.expect, scenario)
+		assert.ErrorIs(t, err, kiku.ErrNotFound, scenario)
+		var staffErrs kiku.StaffErrors
+		assert.True(t, errors.As(err, &staffErrs), scenario)
+		assert.Len(t, staffErrs, 2, scenario)
+	}
+}
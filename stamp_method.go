@@ -0,0 +1,121 @@
+package kiku
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// StampMethod is the integer represents how a stamp was made (打刻方法).
+type StampMethod int
+
+const (
+	// StampMethodUnknown 打刻方法:不明
+	StampMethodUnknown StampMethod = 0
+	// StampMethodBrowser 打刻方法:PCブラウザ
+	StampMethodBrowser StampMethod = 1
+	// StampMethodSmartphone 打刻方法:スマートフォン(GPS)
+	StampMethodSmartphone StampMethod = 2
+	// StampMethodICCard 打刻方法:ICカード
+	StampMethodICCard StampMethod = 3
+	// StampMethodTablet 打刻方法:タブレット(共有端末)
+	StampMethodTablet StampMethod = 4
+	// StampMethodSlack 打刻方法:Slack
+	StampMethodSlack StampMethod = 5
+	// StampMethodAPI 打刻方法:API
+	StampMethodAPI StampMethod = 6
+	// StampMethodCorrection 打刻方法:打刻修正申請
+	StampMethodCorrection StampMethod = 7
+)
+
+var stampMethodNames = map[StampMethod][2]string{
+	StampMethodBrowser:    {"PCブラウザ", "PC browser"},
+	StampMethodSmartphone: {"スマートフォン", "Smartphone"},
+	StampMethodICCard:     {"ICカード", "IC card"},
+	StampMethodTablet:     {"タブレット", "Tablet"},
+	StampMethodSlack:      {"Slack", "Slack"},
+	StampMethodAPI:        {"API", "API"},
+	StampMethodCorrection: {"打刻修正", "Correction"},
+}
+
+// String returns the name of the method in Japanese.
+func (s StampMethod) String() string {
+	return stampMethodNames[s][0]
+}
+
+// English returns the name of the method in English.
+func (s StampMethod) English() string {
+	return stampMethodNames[s][1]
+}
+
+// ParseStampMethod parses the number or the Japanese or English name of the method.
+func ParseStampMethod(v string) (method StampMethod, err error) {
+	if n, e := strconv.Atoi(v); e == nil {
+		return StampMethod(n), nil
+	}
+	for m, names := range stampMethodNames {
+		if v == names[0] || v == names[1] {
+			return m, nil
+		}
+	}
+	err = fmt.Errorf("unknown stamp method: %q", v)
+	return
+}
+
+// MarshalJSON encodes the method as the number AKASHI uses.
+func (s StampMethod) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(s))), nil
+}
+
+// UnmarshalJSON decodes the method from a number, or from a string accepted by ParseStampMethod.
+func (s *StampMethod) UnmarshalJSON(data []byte) (err error) {
+	if bytes.Equal(data, []byte("null")) {
+		return
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var v string
+		if err = json.Unmarshal(data, &v); err != nil {
+			return
+		}
+		*s, err = ParseStampMethod(v)
+		return
+	}
+
+	var n int
+	if err = json.Unmarshal(data, &n); err != nil {
+		return
+	}
+	*s = StampMethod(n)
+	return
+}
+
+// StampsByMethod returns the stamps made by any of methods.
+func (g GetStampResponse) StampsByMethod(methods ...StampMethod) (stamps []Stamp) {
+	for _, s := range g.Stamps {
+		for _, m := range methods {
+			if s.Attributes.Method == m {
+				stamps = append(stamps, s)
+				break
+			}
+		}
+	}
+	return
+}
+
+// StampsExceptMethod returns the stamps made by none of methods.
+func (g GetStampResponse) StampsExceptMethod(methods ...StampMethod) (stamps []Stamp) {
+	for _, s := range g.Stamps {
+		excluded := false
+		for _, m := range methods {
+			if s.Attributes.Method == m {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			stamps = append(stamps, s)
+		}
+	}
+	return
+}
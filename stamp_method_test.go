@@ -0,0 +1,73 @@
+package kiku_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StampMethod_String(t *testing.T) {
+	tests := map[kiku.StampMethod][2]string{
+		kiku.StampMethodUnknown:    {"", ""},
+		kiku.StampMethodICCard:     {"ICカード", "IC card"},
+		kiku.StampMethodSmartphone: {"スマートフォン", "Smartphone"},
+		kiku.StampMethodSlack:      {"Slack", "Slack"},
+	}
+
+	for m, expect := range tests {
+		assert.Equal(t, expect[0], m.String())
+		assert.Equal(t, expect[1], m.English())
+	}
+}
+
+func Test_StampMethod_JSON(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		expect kiku.StampMethod
+		err    bool
+	}{
+		"Number":        {input: `3`, expect: kiku.StampMethodICCard},
+		"Japanese name": {input: `"タブレット"`, expect: kiku.StampMethodTablet},
+		"English name":  {input: `"PC browser"`, expect: kiku.StampMethodBrowser},
+		"Numeric text":  {input: `"5"`, expect: kiku.StampMethodSlack},
+		"Null":          {input: `null`, expect: kiku.StampMethodUnknown},
+		"Unknown name":  {input: `"fax"`, err: true},
+	}
+
+	for scenario, test := range tests {
+		var actual kiku.StampMethod
+		err := json.Unmarshal([]byte(test.input), &actual)
+		assert.Equal(t, test.err, err != nil, scenario)
+		assert.Equal(t, test.expect, actual, scenario)
+	}
+
+	b, err := json.Marshal(kiku.StampAttribute{Method: kiku.StampMethodICCard})
+	assert.NoError(t, err)
+	var attr kiku.StampAttribute
+	assert.NoError(t, json.Unmarshal(b, &attr))
+	assert.Equal(t, kiku.StampMethodICCard, attr.Method)
+	assert.Contains(t, string(b), `"method":3`)
+}
+
+func Test_GetStampResponse_StampsByMethod(t *testing.T) {
+	g := kiku.GetStampResponse{
+		Stamps: []kiku.Stamp{
+			{Type: kiku.StampTypeGoToWork, Attributes: kiku.StampAttribute{Method: kiku.StampMethodICCard}},
+			{Type: kiku.StampTypeBreak, Attributes: kiku.StampAttribute{Method: kiku.StampMethodSlack}},
+			{Type: kiku.StampTypeLeaveWork, Attributes: kiku.StampAttribute{Method: kiku.StampMethodBrowser}},
+		},
+	}
+
+	actual := g.StampsByMethod(kiku.StampMethodICCard, kiku.StampMethodBrowser)
+	assert.Len(t, actual, 2)
+	assert.Equal(t, kiku.StampTypeGoToWork, actual[0].Type)
+	assert.Equal(t, kiku.StampTypeLeaveWork, actual[1].Type)
+
+	actual = g.StampsExceptMethod(kiku.StampMethodICCard, kiku.StampMethodBrowser)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, kiku.StampTypeBreak, actual[0].Type)
+
+	assert.Empty(t, g.StampsByMethod())
+}
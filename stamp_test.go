@@ -1,6 +1,7 @@
 package kiku_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -78,8 +79,10 @@ func Test_GetStampParam_IsValid(t *testing.T) {
 }
 
 func Test_GetStampParam_EncodeURL(t *testing.T) {
-	startDate := time.Date(2000, time.January, 2, 3, 4, 5, 0, time.UTC)
-	endDate := time.Date(2000, time.February, 3, 4, 5, 6, 0, time.UTC)
+	startDate := time.Date(2000, time.January, 2, 3, 4, 5, 0, kiku.Location)
+	endDate := time.Date(2000, time.February, 3, 4, 5, 6, 0, kiku.Location)
+	utcStartDate := time.Date(2000, time.January, 2, 0, 0, 0, 0, time.UTC)
+	utcEndDate := time.Date(2000, time.January, 2, 2, 0, 0, 0, time.UTC)
 	tests := map[string]struct {
 		g      kiku.GetStampParam
 		expect string
@@ -94,6 +97,16 @@ func Test_GetStampParam_EncodeURL(t *testing.T) {
 			},
 			expect: "/foo/stamps/1?end_date=20000203040506&start_date=20000102030405&token=bar",
 		},
+		"Dates in UTC are converted to JST": {
+			g: kiku.GetStampParam{
+				LoginCompanyCode: "foo",
+				Token:            "bar",
+				StartDate:        &utcStartDate,
+				EndDate:          &utcEndDate,
+				StaffID:          1,
+			},
+			expect: "/foo/stamps/1?end_date=20000102110000&start_date=20000102090000&token=bar",
+		},
 		"login_company_code,token,staff_id only set": {
 			g: kiku.GetStampParam{
 				LoginCompanyCode: "foo",
@@ -174,7 +187,7 @@ func Test_PostStampResponse_Decode(t *testing.T) {
 		"Request fail": {
 			input:  strings.NewReader(`{"success":false}`),
 			expect: kiku.PostStampResponse{},
-			err:    errors.New("Requesting Stamp API failed"),
+			err:    &kiku.APIError{},
 		},
 	}
 
@@ -185,3 +198,57 @@ func Test_PostStampResponse_Decode(t *testing.T) {
 		assert.Equal(t, test.expect, actual, scenario)
 	}
 }
+
+func Test_AkTime_JSON(t *testing.T) {
+	expect := kiku.AkTime{Time: time.Date(2000, time.January, 2, 3, 4, 5, 0, kiku.Location)}
+	tests := map[string]struct {
+		input  string
+		expect kiku.AkTime
+		err    bool
+	}{
+		"ReturnDateFormat": {input: `"2000/01/02 03:04:05"`, expect: expect},
+		"DateFormat":       {input: `"20000102030405"`, expect: expect},
+		"Null":             {input: `null`},
+		"Not a string":     {input: `20000102030405`, err: true},
+		"Invalid format":   {input: `"2000-01-02T03:04:05Z"`, err: true},
+	}
+
+	for scenario, test := range tests {
+		var actual kiku.AkTime
+		err := json.Unmarshal([]byte(test.input), &actual)
+		assert.Equal(t, test.err, err != nil, scenario)
+		if !test.err {
+			assert.Equal(t, test.expect, actual, scenario)
+		}
+	}
+
+	b, err := json.Marshal(expect)
+	assert.NoError(t, err)
+	assert.Equal(t, `"20000102030405"`, string(b))
+
+	b, err = json.Marshal(kiku.AkTime{})
+	assert.NoError(t, err)
+	assert.Equal(t, `null`, string(b))
+
+	// The time is formatted in kiku.Location regardless of its own location.
+	b, err = json.Marshal(kiku.AkTime{Time: expect.UTC()})
+	assert.NoError(t, err)
+	assert.Equal(t, `"20000102030405"`, string(b))
+}
+
+func Test_AkTime_RoundTrip(t *testing.T) {
+	expect := kiku.AkTime{Time: time.Date(2000, time.December, 31, 23, 59, 59, 0, kiku.Location)}
+
+	b, err := json.Marshal(expect)
+	assert.NoError(t, err)
+	var actual kiku.AkTime
+	assert.NoError(t, json.Unmarshal(b, &actual))
+	assert.Equal(t, expect, actual)
+
+	text, err := expect.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "20001231235959", string(text))
+	actual = kiku.AkTime{}
+	assert.NoError(t, actual.UnmarshalText(text))
+	assert.Equal(t, expect, actual)
+}
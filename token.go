@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 )
 
 // PostTokenReissueParam トークン再発行リクエストパラメータ
@@ -50,18 +49,27 @@ func (p *PostTokenReissueResponse) Decode(r io.Reader) (err error) {
 
 	err = json.NewDecoder(r).Decode(&psr)
 	switch {
-	case !psr.Success:
-		err = errors.New("Requesting Token Reissue API failed")
-		fallthrough
 	case err != nil:
-		return
+		err = decodeError(err)
+	case !psr.Success:
+		err = &APIError{Errors: psr.Errors}
 	default:
 		*p = psr.Response
 	}
 	return
 }
 
+// PostTokenReissue トークンを再発行
 func PostTokenReissue(ctx context.Context, param PostTokenReissueParam) (res PostTokenReissueResponse, err error) {
+	return defaultClient.PostTokenReissue(ctx, param)
+}
+
+// PostTokenReissue トークンを再発行
+func (c *Client) PostTokenReissue(ctx context.Context, param PostTokenReissueParam) (res PostTokenReissueResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "PostTokenReissue", param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	param.Token = c.accessToken(param.Token)
 	if err = param.IsValid(); err != nil {
 		return
 	}
@@ -71,17 +79,23 @@ func PostTokenReissue(ctx context.Context, param PostTokenReissueParam) (res Pos
 		return
 	}
 
-	cli := newClient()
-	r, err := cli.Post(ctx, endpoint, param)
-	switch {
-	case r.StatusCode != http.StatusOK:
-		err = fmt.Errorf("Status code=%d", r.StatusCode)
-		fallthrough
-	case err != nil:
+	r, err := c.post(ctx, param.LoginCompanyCode, endpoint, param)
+	if err != nil {
 		return
 	}
+	defer r.Body.Close()
 
-	err = res.Decode(r.Body)
+	if err = decodeResponse(r, res.Decode); err != nil {
+		return
+	}
+	if res.LoginCompanyCode == "" {
+		res.LoginCompanyCode = param.LoginCompanyCode
+	}
 
+	if c.tokenStore != nil {
+		if err = c.tokenStore.Save(ctx, res); err != nil {
+			err = &TokenSaveError{Err: err}
+		}
+	}
 	return
 }
@@ -51,7 +51,7 @@ func Test_PostTokenReissueResponse_Decode(t *testing.T) {
 	t.Parallel()
 
 	expiredDate := kiku.AkTime{
-		Time: time.Date(2000, time.January, 2, 3, 4, 5, 0, time.UTC),
+		Time: time.Date(2000, time.January, 2, 3, 4, 5, 0, kiku.Location),
 	}
 
 	tests := map[string]struct {
@@ -73,7 +73,7 @@ func Test_PostTokenReissueResponse_Decode(t *testing.T) {
 		"Failed": {
 			input:  strings.NewReader(`{"success":false,"response":{},"errors":[{"code":"error","message":"error"}]}`),
 			expect: kiku.PostTokenReissueResponse{},
-			err:    errors.New("Requesting Token Reissue API failed"),
+			err:    &kiku.APIError{Errors: []kiku.Error{{Code: "error", Message: "error"}}},
 		},
 	}
 
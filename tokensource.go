@@ -0,0 +1,116 @@
+package kiku
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// TokenSource is the interface that supplies アクセストークン to Client.
+type TokenSource interface {
+	// Token returns the valid access token.
+	Token(ctx context.Context) (string, error)
+}
+
+// tokenRefresher is implemented by TokenSource which can reissue the token on demand.
+type tokenRefresher interface {
+	// Refresh reissues the token unless stale has already been replaced.
+	Refresh(ctx context.Context, stale string) (string, error)
+}
+
+// ReissueTokenSource is the TokenSource that reissues the token with PostTokenReissue before it expires.
+// A ReissueTokenSource is safe for concurrent use by multiple goroutines.
+type ReissueTokenSource struct {
+	client           *Client
+	loginCompanyCode string
+	margin           time.Duration
+
+	mu        sync.Mutex
+	token     string
+	expiredAt time.Time
+}
+
+// NewReissueTokenSource returns the ReissueTokenSource starting from token which expires at expiredAt.
+// The token is reissued margin before expiredAt. A zero expiredAt means the expiry is unknown.
+func NewReissueTokenSource(client *Client, loginCompanyCode, token string, expiredAt time.Time, margin time.Duration) *ReissueTokenSource {
+	return &ReissueTokenSource{
+		client:           client,
+		loginCompanyCode: loginCompanyCode,
+		margin:           margin,
+		token:            token,
+		expiredAt:        expiredAt,
+	}
+}
+
+// Token implements TokenSource.
+func (s *ReissueTokenSource) Token(ctx context.Context) (token string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.expiredAt.IsZero() || time.Now().Add(s.margin).Before(s.expiredAt) {
+		return s.token, nil
+	}
+	return s.reissue(ctx)
+}
+
+// Refresh reissues the token immediately unless stale has already been replaced by another goroutine.
+func (s *ReissueTokenSource) Refresh(ctx context.Context, stale string) (token string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != stale {
+		return s.token, nil
+	}
+	return s.reissue(ctx)
+}
+
+// reissue reissues the token. s.mu must be held.
+// A reissued token which cannot be saved to TokenStore is still used, since AKASHI has already
+// revoked the old one. The failure is logged by the Logger of the client instead of being returned.
+func (s *ReissueTokenSource) reissue(ctx context.Context) (token string, err error) {
+	res, err := s.client.PostTokenReissue(ctx, PostTokenReissueParam{
+		LoginCompanyCode: s.loginCompanyCode,
+		Token:            s.token,
+	})
+	var saveErr *TokenSaveError
+	if errors.As(err, &saveErr) && res.Token != "" {
+		s.client.logger.Error("kiku: reissued token is not saved", "error", err)
+		err = nil
+	}
+	if err != nil {
+		return
+	}
+
+	s.token = res.Token
+	s.expiredAt = time.Time{}
+	if res.ExpiredAt != nil {
+		s.expiredAt = res.ExpiredAt.Time
+	}
+	return s.token, nil
+}
+
+// authorize calls call after setting the access token to *token unless it is given explicitly.
+// When the token supplied by TokenSource has expired, it is reissued and call is retried once.
+func (c *Client) authorize(ctx context.Context, token *string, call func() error) (err error) {
+	if *token != "" {
+		return call()
+	}
+	if c.tokenSource == nil {
+		*token = c.token
+		return call()
+	}
+
+	if *token, err = c.tokenSource.Token(ctx); err != nil {
+		return
+	}
+	err = call()
+	r, ok := c.tokenSource.(tokenRefresher)
+	if !ok || !errors.Is(err, ErrTokenExpired) {
+		return
+	}
+	if *token, err = r.Refresh(ctx, *token); err != nil {
+		return
+	}
+	return call()
+}
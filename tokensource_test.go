@@ -0,0 +1,178 @@
+package kiku_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTokenServer returns the server which accepts only the latest token.
+// A token of the form "tokenN" is reissued as "tokenN+1".
+func newTokenServer(reissued *int32) *httptest.Server {
+	var (
+		mu      sync.Mutex
+		current = "token0"
+	)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if strings.HasPrefix(r.URL.Path, "/token/reissue/") {
+			var body struct {
+				Token string `json:"token"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Token != current {
+				io.WriteString(w, `{"success":false,"errors":[{"code":"UNAUTHORIZED","message":"invalid"}]}`)
+				return
+			}
+			n := atomic.AddInt32(reissued, 1)
+			current = fmt.Sprintf("token%d", n)
+			fmt.Fprintf(w, `{"success":true,"response":{"token":%q,"expired_at":%q}}`,
+				current, time.Now().Add(time.Hour).In(kiku.Location).Format(kiku.ReturnDateFormat))
+			return
+		}
+
+		if r.URL.Query().Get("token") != current {
+			io.WriteString(w, `{"success":false,"errors":[{"code":"TOKEN_EXPIRED","message":"expired"}]}`)
+			return
+		}
+		io.WriteString(w, `{"success":true,"response":{"login_company_code":"foo"}}`)
+	}))
+}
+
+func Test_ReissueTokenSource_Token(t *testing.T) {
+	tests := map[string]struct {
+		expiredAt time.Time
+		expect    string
+		reissued  int32
+	}{
+		"Token is valid": {
+			expiredAt: time.Now().Add(time.Hour),
+			expect:    "token0",
+		},
+		"Token is about to expire": {
+			expiredAt: time.Now().Add(time.Minute),
+			expect:    "token1",
+			reissued:  1,
+		},
+		"Expiry is unknown": {
+			expect: "token0",
+		},
+	}
+
+	for scenario, test := range tests {
+		var reissued int32
+		ts := newTokenServer(&reissued)
+		cli := kiku.NewClient(kiku.WithBaseURL(ts.URL), kiku.WithHTTPClient(ts.Client()), kiku.WithLogger(nil))
+		source := kiku.NewReissueTokenSource(cli, "foo", "token0", test.expiredAt, 5*time.Minute)
+
+		actual, err := source.Token(context.Background())
+		ts.Close()
+
+		assert.NoError(t, err, scenario)
+		assert.Equal(t, test.expect, actual, scenario)
+		assert.Equal(t, test.reissued, reissued, scenario)
+	}
+}
+
+func Test_ReissueTokenSource_Concurrent(t *testing.T) {
+	var reissued int32
+	ts := newTokenServer(&reissued)
+	defer ts.Close()
+
+	cli := kiku.NewClient(kiku.WithBaseURL(ts.URL), kiku.WithHTTPClient(ts.Client()), kiku.WithLogger(nil))
+	source := kiku.NewReissueTokenSource(cli, "foo", "token0", time.Now(), time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := source.Token(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "token1", token)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), reissued)
+}
+
+func Test_Client_TokenSource(t *testing.T) {
+	var reissued int32
+	ts := newTokenServer(&reissued)
+	defer ts.Close()
+
+	cli := kiku.NewClient(kiku.WithBaseURL(ts.URL), kiku.WithHTTPClient(ts.Client()), kiku.WithLogger(nil))
+	// The server has already forgotten "token0" once it is reissued below.
+	_, err := cli.PostTokenReissue(context.Background(), kiku.PostTokenReissueParam{LoginCompanyCode: "foo", Token: "token0"})
+	assert.NoError(t, err)
+
+	source := kiku.NewReissueTokenSource(cli, "foo", "token1", time.Time{}, 0)
+	cli = kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLogger(nil),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithTokenSource(source),
+	)
+
+	// "token1" is valid.
+	_, err = cli.GetStaff(context.Background(), kiku.GetStaffParam{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), reissued)
+
+	// Another process reissues the token, so "token1" is reported as expired and reissue fails.
+	_, err = cli.PostTokenReissue(context.Background(), kiku.PostTokenReissueParam{Token: "token1"})
+	assert.NoError(t, err)
+	_, err = cli.GetStaff(context.Background(), kiku.GetStaffParam{})
+	assert.ErrorIs(t, err, kiku.ErrUnauthorized)
+}
+
+func Test_Client_TokenSource_RetryAfterReissue(t *testing.T) {
+	var (
+		reissued int32
+		requests int32
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/token/reissue/") {
+			atomic.AddInt32(&reissued, 1)
+			io.WriteString(w, `{"success":true,"response":{"token":"new"}}`)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Query().Get("token") != "new" {
+			io.WriteString(w, `{"success":false,"errors":[{"code":"TOKEN_EXPIRED","message":"expired"}]}`)
+			return
+		}
+		io.WriteString(w, `{"success":true,"response":{"staff_id":1}}`)
+	}))
+	defer ts.Close()
+
+	cli := kiku.NewClient(kiku.WithBaseURL(ts.URL), kiku.WithHTTPClient(ts.Client()), kiku.WithLogger(nil))
+	cli = kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLogger(nil),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithTokenSource(kiku.NewReissueTokenSource(cli, "foo", "old", time.Time{}, 0)),
+	)
+
+	start, end := time.Now(), time.Now()
+	actual, err := cli.GetStamps(context.Background(), kiku.GetStampParam{StartDate: &start, EndDate: &end, StaffID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, actual.StaffID)
+	assert.Equal(t, int32(1), reissued)
+	assert.Equal(t, int32(2), requests)
+}
@@ -0,0 +1,183 @@
+package kiku
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrTokenNotFound is returned by TokenStore when no token is stored for the key.
+var ErrTokenNotFound = errors.New("kiku: token not found")
+
+// TokenSaveError is the error returned when a reissued token cannot be saved to TokenStore.
+// The reissued token is returned along with it and must be used, since AKASHI has already revoked the old one.
+type TokenSaveError struct {
+	Err error // TokenStoreが返したエラー
+}
+
+func (e *TokenSaveError) Error() string {
+	return fmt.Sprintf("saving reissued token: %v", e.Err)
+}
+
+func (e *TokenSaveError) Unwrap() error {
+	return e.Err
+}
+
+// TokenStore is the interface that persists reissued access tokens.
+// Tokens are keyed by AKASHI企業ID and 従業員ID.
+type TokenStore interface {
+	// Load returns the token stored for loginCompanyCode and staffID, or ErrTokenNotFound.
+	Load(ctx context.Context, loginCompanyCode string, staffID int) (PostTokenReissueResponse, error)
+	// Save stores the token keyed by its LoginCompanyCode and StaffId.
+	Save(ctx context.Context, token PostTokenReissueResponse) error
+}
+
+type tokenKey struct {
+	loginCompanyCode string
+	staffID          int
+}
+
+// MemoryTokenStore is the TokenStore keeping tokens in memory.
+// A MemoryTokenStore is safe for concurrent use by multiple goroutines.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[tokenKey]PostTokenReissueResponse
+}
+
+// NewMemoryTokenStore returns the empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: map[tokenKey]PostTokenReissueResponse{},
+	}
+}
+
+// Load implements TokenStore.
+func (m *MemoryTokenStore) Load(ctx context.Context, loginCompanyCode string, staffID int) (token PostTokenReissueResponse, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	token, ok := m.tokens[tokenKey{loginCompanyCode, staffID}]
+	if !ok {
+		err = ErrTokenNotFound
+	}
+	return
+}
+
+// Save implements TokenStore.
+func (m *MemoryTokenStore) Save(ctx context.Context, token PostTokenReissueResponse) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tokens[tokenKey{token.LoginCompanyCode, token.StaffId}] = token
+	return
+}
+
+// FileTokenStore is the TokenStore writing each token to a file in Dir.
+// Files are replaced atomically and readable only by the owner.
+type FileTokenStore struct {
+	Dir string // トークンを保存するディレクトリ
+}
+
+// storedToken is the file format of FileTokenStore.
+type storedToken struct {
+	LoginCompanyCode string     `json:"login_company_code"`
+	StaffID          int        `json:"staff_id"`
+	AgencyManagerID  int        `json:"agency_manager_id"`
+	Token            string     `json:"token"`
+	ExpiredAt        *time.Time `json:"expired_at,omitempty"`
+}
+
+func (f FileTokenStore) path(loginCompanyCode string, staffID int) string {
+	return filepath.Join(f.Dir, fmt.Sprintf("%s_%d.json", url.PathEscape(loginCompanyCode), staffID))
+}
+
+// Load implements TokenStore.
+func (f FileTokenStore) Load(ctx context.Context, loginCompanyCode string, staffID int) (token PostTokenReissueResponse, err error) {
+	b, err := os.ReadFile(f.path(loginCompanyCode, staffID))
+	if errors.Is(err, os.ErrNotExist) {
+		err = ErrTokenNotFound
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	var st storedToken
+	if err = json.Unmarshal(b, &st); err != nil {
+		return
+	}
+	token = PostTokenReissueResponse{
+		LoginCompanyCode: st.LoginCompanyCode,
+		StaffId:          st.StaffID,
+		AgencyManagerId:  st.AgencyManagerID,
+		Token:            st.Token,
+	}
+	if st.ExpiredAt != nil {
+		token.ExpiredAt = &AkTime{*st.ExpiredAt}
+	}
+	return
+}
+
+// Save implements TokenStore.
+func (f FileTokenStore) Save(ctx context.Context, token PostTokenReissueResponse) (err error) {
+	st := storedToken{
+		LoginCompanyCode: token.LoginCompanyCode,
+		StaffID:          token.StaffId,
+		AgencyManagerID:  token.AgencyManagerId,
+		Token:            token.Token,
+	}
+	if token.ExpiredAt != nil {
+		st.ExpiredAt = &token.ExpiredAt.Time
+	}
+	b, err := json.Marshal(st)
+	if err != nil {
+		return
+	}
+
+	if err = os.MkdirAll(f.Dir, 0o700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(f.Dir, ".token-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if err = tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return
+	}
+	if _, err = tmp.Write(b); err != nil {
+		tmp.Close()
+		return
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		return
+	}
+	return os.Rename(tmp.Name(), f.path(token.LoginCompanyCode, token.StaffId))
+}
+
+// LoadReissueTokenSource returns the ReissueTokenSource starting from the token stored in store.
+func LoadReissueTokenSource(ctx context.Context, client *Client, store TokenStore, loginCompanyCode string, staffID int, margin time.Duration) (source *ReissueTokenSource, err error) {
+	token, err := store.Load(ctx, loginCompanyCode, staffID)
+	if err != nil {
+		return
+	}
+
+	var expiredAt time.Time
+	if token.ExpiredAt != nil {
+		expiredAt = token.ExpiredAt.Time
+	}
+	source = NewReissueTokenSource(client, loginCompanyCode, token.Token, expiredAt, margin)
+	return
+}
@@ -0,0 +1,152 @@
+package kiku_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TokenStore(t *testing.T) {
+	expiredAt := kiku.AkTime{Time: time.Date(2000, time.January, 2, 3, 4, 5, 0, time.UTC)}
+	token := kiku.PostTokenReissueResponse{
+		LoginCompanyCode: "foo",
+		StaffId:          123,
+		AgencyManagerId:  456,
+		Token:            "bar",
+		ExpiredAt:        &expiredAt,
+	}
+	tests := map[string]kiku.TokenStore{
+		"MemoryTokenStore": kiku.NewMemoryTokenStore(),
+		"FileTokenStore":   kiku.FileTokenStore{Dir: filepath.Join(t.TempDir(), "tokens")},
+	}
+
+	for scenario, store := range tests {
+		ctx := context.Background()
+
+		_, err := store.Load(ctx, "foo", 123)
+		assert.ErrorIs(t, err, kiku.ErrTokenNotFound, scenario)
+
+		assert.NoError(t, store.Save(ctx, token), scenario)
+		actual, err := store.Load(ctx, "foo", 123)
+		assert.NoError(t, err, scenario)
+		assert.Equal(t, token.Token, actual.Token, scenario)
+		assert.True(t, token.ExpiredAt.Equal(actual.ExpiredAt.Time), scenario)
+
+		_, err = store.Load(ctx, "foo", 789)
+		assert.ErrorIs(t, err, kiku.ErrTokenNotFound, scenario)
+	}
+}
+
+func Test_FileTokenStore_Permission(t *testing.T) {
+	dir := t.TempDir()
+	store := kiku.FileTokenStore{Dir: dir}
+	assert.NoError(t, store.Save(context.Background(), kiku.PostTokenReissueResponse{LoginCompanyCode: "foo", StaffId: 1, Token: "bar"}))
+	assert.NoError(t, store.Save(context.Background(), kiku.PostTokenReissueResponse{LoginCompanyCode: "foo", StaffId: 1, Token: "baz"}))
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	info, err := os.Stat(filepath.Join(dir, entries[0].Name()))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func Test_Client_TokenStore(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"success":true,"response":{"staff_id":123,"token":"new","expired_at":"2000/01/02 03:04:05"}}`)
+	}))
+	defer ts.Close()
+
+	store := kiku.NewMemoryTokenStore()
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLogger(nil),
+		kiku.WithTokenStore(store),
+	)
+
+	res, err := cli.PostTokenReissue(context.Background(), kiku.PostTokenReissueParam{LoginCompanyCode: "foo", Token: "old"})
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", res.LoginCompanyCode)
+
+	stored, err := store.Load(context.Background(), "foo", 123)
+	assert.NoError(t, err)
+	assert.Equal(t, res, stored)
+
+	source, err := kiku.LoadReissueTokenSource(context.Background(), cli, store, "foo", 123, 0)
+	assert.NoError(t, err)
+	token, err := source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "new", token)
+
+	_, err = kiku.LoadReissueTokenSource(context.Background(), cli, store, "foo", 456, 0)
+	assert.ErrorIs(t, err, kiku.ErrTokenNotFound)
+}
+
+// failingTokenStore is the TokenStore whose Save always fails.
+type failingTokenStore struct {
+	kiku.TokenStore
+}
+
+func (failingTokenStore) Save(ctx context.Context, token kiku.PostTokenReissueResponse) error {
+	return errors.New("disk full")
+}
+
+func Test_ReissueTokenSource_SaveFailure(t *testing.T) {
+	var reissued int32
+	ts := newTokenServer(&reissued)
+	defer ts.Close()
+
+	logger := &recordingLogger{}
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLogger(logger),
+		kiku.WithTokenStore(failingTokenStore{}),
+	)
+
+	// PostTokenReissue returns the reissued token along with *TokenSaveError.
+	res, err := cli.PostTokenReissue(context.Background(), kiku.PostTokenReissueParam{LoginCompanyCode: "foo", Token: "token0"})
+	var saveErr *kiku.TokenSaveError
+	assert.True(t, errors.As(err, &saveErr))
+	assert.EqualError(t, saveErr.Err, "disk full")
+	assert.Equal(t, "token1", res.Token)
+
+	// ReissueTokenSource keeps using the reissued token and only logs the failure.
+	source := kiku.NewReissueTokenSource(cli, "foo", "token1", time.Now(), time.Minute)
+	token, err := source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token2", token)
+	assert.Contains(t, logger.String(), "kiku: reissued token is not saved")
+
+	token, err = source.Refresh(context.Background(), "token2")
+	assert.NoError(t, err)
+	assert.Equal(t, "token3", token)
+
+	token, err = source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token3", token)
+	assert.Equal(t, int32(3), reissued)
+
+	// An API call proceeds with the token reissued before it.
+	cli = kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLogger(nil),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithTokenSource(kiku.NewReissueTokenSource(cli, "foo", "token3", time.Now(), time.Minute)),
+	)
+	_, err = cli.GetStaff(context.Background(), kiku.GetStaffParam{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), reissued)
+}
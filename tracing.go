@@ -0,0 +1,131 @@
+package kiku
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Attribute keys set on the spans of API calls.
+const (
+	AttributeCompanyCode = "akashi.company_code"
+	AttributeStaffID     = "akashi.staff_id"
+	AttributeErrorCodes  = "akashi.error_codes"
+	AttributeStatusCode  = "http.status_code"
+	AttributeAttempts    = "kiku.attempts"
+)
+
+// Attribute is the key-value pair describing a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Tracer is the interface for starting a span per API call, such as an adapter of OpenTelemetry.
+type Tracer interface {
+	// Start starts the span named name, such as "kiku.GetStamps", as a child of the span in ctx.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is the interface for a span started by Tracer.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Meter is the interface for recording the latency and the result of every API call.
+type Meter interface {
+	// RecordCall records the API call named name, such as "kiku.GetStamps", which took duration and returned err.
+	RecordCall(ctx context.Context, name string, duration time.Duration, err error)
+}
+
+// WithTracer sets the tracer that starts a span per API call.
+// Spans carry the company code, the staff ID, the HTTP status and AKASHI error codes, but never the token.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+// WithMeter sets the meter that records the latency and the result of every API call.
+func WithMeter(meter Meter) Option {
+	return func(c *Client) {
+		c.meter = meter
+	}
+}
+
+// apiCall is the instrumentation of an API call.
+type apiCall struct {
+	client   *Client
+	name     string
+	start    time.Time
+	span     Span
+	attempts int
+}
+
+type apiCallKey struct{}
+
+// startCall starts the instrumentation of the API call op. staffID is omitted when it is 0.
+// The returned apiCall is nil if neither Tracer nor Meter is set.
+func (c *Client) startCall(ctx context.Context, op, companyCode string, staffID int) (context.Context, *apiCall) {
+	if c.tracer == nil && c.meter == nil {
+		return ctx, nil
+	}
+
+	call := &apiCall{client: c, name: "kiku." + op, start: time.Now()}
+	if c.tracer != nil {
+		attrs := []Attribute{{Key: AttributeCompanyCode, Value: companyCode}}
+		if staffID != 0 {
+			attrs = append(attrs, Attribute{Key: AttributeStaffID, Value: staffID})
+		}
+		ctx, call.span = c.tracer.Start(ctx, call.name, attrs...)
+	}
+	return context.WithValue(ctx, apiCallKey{}, call), call
+}
+
+// recordAttempt records an attempt of the API call in ctx and its HTTP status.
+func recordAttempt(ctx context.Context, res *http.Response, err error) {
+	call, ok := ctx.Value(apiCallKey{}).(*apiCall)
+	if !ok {
+		return
+	}
+	call.attempts++
+	if call.span != nil && err == nil {
+		call.span.SetAttributes(Attribute{Key: AttributeStatusCode, Value: res.StatusCode})
+	}
+}
+
+// end finishes the instrumentation with the result of the API call.
+func (a *apiCall) end(ctx context.Context, err error) {
+	if a == nil {
+		return
+	}
+
+	if a.span != nil {
+		a.span.SetAttributes(Attribute{Key: AttributeAttempts, Value: a.attempts})
+		if err != nil {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && len(apiErr.Errors) > 0 {
+				codes := make([]string, len(apiErr.Errors))
+				for i, e := range apiErr.Errors {
+					codes[i] = e.Code
+				}
+				a.span.SetAttributes(Attribute{Key: AttributeErrorCodes, Value: codes})
+			}
+			a.span.RecordError(err)
+		}
+		a.span.End()
+	}
+	if a.client.meter != nil {
+		a.client.meter.RecordCall(ctx, a.name, time.Since(a.start), err)
+	}
+}
+
+func intValue(p *int) int {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
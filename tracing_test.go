@@ -0,0 +1,185 @@
+package kiku_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedSpan struct {
+	name   string
+	parent string
+	attrs  map[string]interface{}
+	errs   []error
+	ended  bool
+}
+
+type spanKey struct{}
+
+// memoryTracer is the Tracer and Meter keeping every span and call in memory.
+type memoryTracer struct {
+	mu     sync.Mutex
+	spans  []*recordedSpan
+	calls  map[string]int
+	errors map[string]int
+}
+
+func (m *memoryTracer) Start(ctx context.Context, name string, attrs ...kiku.Attribute) (context.Context, kiku.Span) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := &recordedSpan{name: name, attrs: map[string]interface{}{}}
+	if parent, ok := ctx.Value(spanKey{}).(*recordedSpan); ok {
+		s.parent = parent.name
+	}
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+	m.spans = append(m.spans, s)
+	return context.WithValue(ctx, spanKey{}, s), &memorySpan{tracer: m, span: s}
+}
+
+func (m *memoryTracer) RecordCall(ctx context.Context, name string, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.calls == nil {
+		m.calls, m.errors = map[string]int{}, map[string]int{}
+	}
+	m.calls[name]++
+	if err != nil {
+		m.errors[name]++
+	}
+}
+
+type memorySpan struct {
+	tracer *memoryTracer
+	span   *recordedSpan
+}
+
+func (s *memorySpan) SetAttributes(attrs ...kiku.Attribute) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	for _, a := range attrs {
+		s.span.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *memorySpan) RecordError(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.span.errs = append(s.span.errs, err)
+}
+
+func (s *memorySpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.span.ended = true
+}
+
+func Test_Client_Tracer(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/foo/stamps/1":
+			if requests == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			io.WriteString(w, `{"success":true,"response":{"login_company_code":"foo","staff_id":1}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"success":false,"errors":[{"code":"NOT_FOUND","message":"not found"}]}`)
+		}
+	}))
+	defer ts.Close()
+
+	tracer := &memoryTracer{}
+	cli := kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithToken("secret"),
+		kiku.WithLogger(nil),
+		kiku.WithRetryPolicy(kiku.ExponentialBackoff{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		kiku.WithTracer(tracer),
+		kiku.WithMeter(tracer),
+	)
+
+	start, end := time.Now(), time.Now()
+	_, err := cli.GetStamps(context.Background(), kiku.GetStampParam{StartDate: &start, EndDate: &end, StaffID: 1})
+	assert.NoError(t, err)
+	_, err = cli.GetWorkplaces(context.Background(), kiku.GetWorkplacesParam{})
+	assert.ErrorIs(t, err, kiku.ErrNotFound)
+
+	assert.Len(t, tracer.spans, 2)
+	tests := map[string]struct {
+		span   *recordedSpan
+		name   string
+		attrs  map[string]interface{}
+		errors int
+	}{
+		"Retried call": {
+			span: tracer.spans[0],
+			name: "kiku.GetStamps",
+			attrs: map[string]interface{}{
+				kiku.AttributeCompanyCode: "foo",
+				kiku.AttributeStaffID:     1,
+				kiku.AttributeStatusCode:  http.StatusOK,
+				kiku.AttributeAttempts:    2,
+			},
+		},
+		"Failed call": {
+			span: tracer.spans[1],
+			name: "kiku.GetWorkplaces",
+			attrs: map[string]interface{}{
+				kiku.AttributeCompanyCode: "foo",
+				kiku.AttributeStatusCode:  http.StatusNotFound,
+				kiku.AttributeAttempts:    1,
+				kiku.AttributeErrorCodes:  []string{kiku.ErrorCodeNotFound},
+			},
+			errors: 1,
+		},
+	}
+	for scenario, test := range tests {
+		assert.Equal(t, test.name, test.span.name, scenario)
+		assert.Equal(t, test.attrs, test.span.attrs, scenario)
+		assert.Len(t, test.span.errs, test.errors, scenario)
+		assert.True(t, test.span.ended, scenario)
+		assert.NotContains(t, fmt.Sprint(test.span.attrs, test.span.errs), "secret", scenario)
+	}
+
+	assert.Equal(t, map[string]int{"kiku.GetStamps": 1, "kiku.GetWorkplaces": 1}, tracer.calls)
+	assert.Equal(t, map[string]int{"kiku.GetWorkplaces": 1}, tracer.errors)
+}
+
+func Test_Client_Tracer_TokenReissue(t *testing.T) {
+	var reissued int32
+	ts := newTokenServer(&reissued)
+	defer ts.Close()
+
+	tracer := &memoryTracer{}
+	cli := kiku.NewClient(kiku.WithBaseURL(ts.URL), kiku.WithHTTPClient(ts.Client()), kiku.WithLogger(nil), kiku.WithTracer(tracer))
+	cli = kiku.NewClient(
+		kiku.WithBaseURL(ts.URL),
+		kiku.WithHTTPClient(ts.Client()),
+		kiku.WithLogger(nil),
+		kiku.WithLoginCompanyCode("foo"),
+		kiku.WithTokenSource(kiku.NewReissueTokenSource(cli, "foo", "token0", time.Now(), time.Minute)),
+		kiku.WithTracer(tracer),
+	)
+
+	_, err := cli.GetStaff(context.Background(), kiku.GetStaffParam{})
+	assert.NoError(t, err)
+	assert.Len(t, tracer.spans, 2)
+	assert.Equal(t, "kiku.GetStaff", tracer.spans[0].name)
+	assert.Equal(t, "kiku.PostTokenReissue", tracer.spans[1].name)
+	assert.Equal(t, "kiku.GetStaff", tracer.spans[1].parent)
+}
@@ -0,0 +1,130 @@
+package kiku
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+)
+
+// earthRadius is the mean radius of the earth in meters.
+const earthRadius = 6371000
+
+// Workplace is the struct representing a workplace (勤務地).
+type Workplace struct {
+	ID        int     `json:"workplace_id"` // 勤務地ID
+	Name      string  `json:"name"`         // 勤務地名
+	Address   string  `json:"address"`      // 住所
+	Latitude  float64 `json:"latitude"`     // 登録された緯度
+	Longitude float64 `json:"longitude"`    // 登録された経度
+	Radius    float64 `json:"radius"`       // 打刻を許可する半径(メートル)
+}
+
+// GetWorkplacesParam is the struct for the request parameters of GET Workplace API.
+type GetWorkplacesParam struct {
+	LoginCompanyCode string // AKASHI企業ID
+	Token            string // アクセストークン
+}
+
+// IsValid is the function to verify GetWorkplacesParam is correct.
+func (g GetWorkplacesParam) IsValid() (err error) {
+	switch {
+	case g.LoginCompanyCode == "":
+		err = errors.New("LoginCompanyCode must be set")
+	case g.Token == "":
+		err = errors.New("Token must be set")
+	}
+	return
+}
+
+// EncodeURL is the function that encodes the request URL of GET Workplace API.
+func (g GetWorkplacesParam) EncodeURL() (encodedURL string) {
+	uv := url.Values{}
+	uv.Add("token", g.Token)
+	encodedURL = fmt.Sprintf("/%s/workplaces?%s", g.LoginCompanyCode, uv.Encode())
+	return
+}
+
+// GetWorkplacesResponse is the struct representing the response of GET Workplace API.
+type GetWorkplacesResponse struct {
+	LoginCompanyCode string      `json:"login_company_code"` // AKASHI企業ID
+	Count            int         `json:"count"`              // 勤務地数
+	Workplaces       []Workplace `json:"workplaces"`         // 勤務地の配列
+}
+
+// Decode is the function that decodes the response of GET Workplace API.
+func (g *GetWorkplacesResponse) Decode(r io.Reader) (err error) {
+	return decodeEnvelope(r, g)
+}
+
+// GetWorkplaces is the function that retrieves the workplaces from AKASHI.
+func GetWorkplaces(ctx context.Context, param GetWorkplacesParam) (response GetWorkplacesResponse, err error) {
+	return defaultClient.GetWorkplaces(ctx, param)
+}
+
+// GetWorkplaces is the method that retrieves the workplaces from AKASHI.
+func (c *Client) GetWorkplaces(ctx context.Context, param GetWorkplacesParam) (response GetWorkplacesResponse, err error) {
+	param.LoginCompanyCode = c.companyCode(param.LoginCompanyCode)
+	ctx, call := c.startCall(ctx, "GetWorkplaces", param.LoginCompanyCode, 0)
+	defer func() { call.end(ctx, err) }()
+	err = c.authorize(ctx, &param.Token, func() (err error) {
+		if err = param.IsValid(); err != nil {
+			return
+		}
+
+		res, err := c.get(ctx, param.LoginCompanyCode, param.EncodeURL())
+		if err != nil {
+			return
+		}
+		defer res.Body.Close()
+
+		return decodeResponse(res, response.Decode)
+	})
+	return
+}
+
+// AnnotatedStamp is the struct representing a stamp with its resolved workplace.
+type AnnotatedStamp struct {
+	Stamp
+	Workplace    *Workplace // 打刻の勤務地(解決できない場合はnil)
+	Located      bool       // 打刻に位置情報があり、勤務地との距離が計算できたか
+	Distance     float64    // 打刻位置と勤務地の登録位置との距離(メートル)
+	WithinRadius bool       // 打刻位置が勤務地の半径内にあるか
+}
+
+// AnnotateStamps resolves StampAttribute.WorkplaceID of each stamp with workplaces,
+// and measures the distance between the stamp position and the registered workplace location.
+func AnnotateStamps(stamps []Stamp, workplaces []Workplace) []AnnotatedStamp {
+	index := make(map[int]*Workplace, len(workplaces))
+	for i := range workplaces {
+		index[workplaces[i].ID] = &workplaces[i]
+	}
+
+	annotated := make([]AnnotatedStamp, len(stamps))
+	for i, s := range stamps {
+		a := AnnotatedStamp{Stamp: s, Workplace: index[s.Attributes.WorkplaceID]}
+		hasPosition := s.Attributes.Latitude != 0 || s.Attributes.Longitude != 0
+		if a.Workplace != nil && hasPosition {
+			a.Located = true
+			a.Distance = Distance(
+				float64(s.Attributes.Latitude), float64(s.Attributes.Longitude),
+				a.Workplace.Latitude, a.Workplace.Longitude,
+			)
+			a.WithinRadius = a.Distance <= a.Workplace.Radius
+		}
+		annotated[i] = a
+	}
+	return annotated
+}
+
+// Distance returns the great-circle distance in meters between two coordinates given in degrees.
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := rad(lat2 - lat1)
+	dLon := rad(lon2 - lon1)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
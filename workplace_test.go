@@ -0,0 +1,65 @@
+package kiku_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hapoon/kiku"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetWorkplacesParam_EncodeURL(t *testing.T) {
+	g := kiku.GetWorkplacesParam{LoginCompanyCode: "foo", Token: "bar"}
+	assert.Equal(t, "/foo/workplaces?token=bar", g.EncodeURL())
+}
+
+func Test_GetWorkplacesResponse_Decode(t *testing.T) {
+	var actual kiku.GetWorkplacesResponse
+	err := actual.Decode(strings.NewReader(`{"success":true,"response":{"count":1,"workplaces":[` +
+		`{"workplace_id":1,"name":"本社","address":"東京都千代田区","latitude":35.681236,"longitude":139.767125,"radius":200}]}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []kiku.Workplace{
+		{ID: 1, Name: "本社", Address: "東京都千代田区", Latitude: 35.681236, Longitude: 139.767125, Radius: 200},
+	}, actual.Workplaces)
+}
+
+func Test_Distance(t *testing.T) {
+	// 東京駅 - 新大阪駅 is about 403km.
+	d := kiku.Distance(35.681236, 139.767125, 34.733165, 135.500214)
+	assert.InDelta(t, 403000, d, 2000)
+	assert.Equal(t, 0.0, kiku.Distance(35, 139, 35, 139))
+}
+
+func Test_AnnotateStamps(t *testing.T) {
+	workplaces := []kiku.Workplace{
+		{ID: 1, Name: "本社", Latitude: 35.681236, Longitude: 139.767125, Radius: 200},
+	}
+	stamps := []kiku.Stamp{
+		{Attributes: kiku.StampAttribute{WorkplaceID: 1, Latitude: 35.6813, Longitude: 139.7672}},
+		{Attributes: kiku.StampAttribute{WorkplaceID: 1, Latitude: 35.69, Longitude: 139.77}},
+		{Attributes: kiku.StampAttribute{WorkplaceID: 1}},
+		{Attributes: kiku.StampAttribute{WorkplaceID: 2, Latitude: 35.6813, Longitude: 139.7672}},
+	}
+
+	actual := kiku.AnnotateStamps(stamps, workplaces)
+	assert.Len(t, actual, 4)
+
+	tests := map[string]struct {
+		index        int
+		workplace    bool
+		located      bool
+		withinRadius bool
+	}{
+		"Near the workplace": {index: 0, workplace: true, located: true, withinRadius: true},
+		"Far from workplace": {index: 1, workplace: true, located: true},
+		"Without position":   {index: 2, workplace: true},
+		"Unknown workplace":  {index: 3},
+	}
+	for scenario, test := range tests {
+		a := actual[test.index]
+		assert.Equal(t, test.workplace, a.Workplace != nil, scenario)
+		assert.Equal(t, test.located, a.Located, scenario)
+		assert.Equal(t, test.withinRadius, a.WithinRadius, scenario)
+	}
+	assert.Greater(t, actual[1].Distance, 200.0)
+}